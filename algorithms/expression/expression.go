@@ -0,0 +1,381 @@
+package expression
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lucasturci/everything-go/data-structures/stack"
+)
+
+// Error is a parse or evaluation error at a byte offset of the expression
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+func errorf(pos int, format string, args ...any) *Error {
+	return &Error{pos, fmt.Sprintf(format, args...)}
+}
+
+type Kind int
+
+const (
+	Number Kind = iota
+	Operator
+	Function
+	LeftParen
+	RightParen
+	Comma
+)
+
+type Token struct {
+	Kind  Kind
+	Text  string
+	Value float64 // for numbers
+	Pos   int
+	// Arity is the number of arguments of a function call, known once the call is
+	// parsed
+	Arity int
+}
+
+// The operator Text of unary minus, to tell it apart from subtraction
+const _neg = "neg"
+
+type operator struct {
+	precedence int
+	rightAssoc bool
+	unary      bool
+}
+
+// Unary minus binds tighter than multiplication but looser than exponentiation, so
+// -2^2 is -(2^2)
+var operators = map[string]operator{
+	"+":  {1, false, false},
+	"-":  {1, false, false},
+	"*":  {2, false, false},
+	"/":  {2, false, false},
+	"%":  {2, false, false},
+	_neg: {3, true, true},
+	"^":  {4, true, false},
+}
+
+type function struct {
+	arity int
+	fn    func(args ...float64) float64
+}
+
+// Evaluator parses and evaluates infix arithmetic on float64s, with + - * / % ^,
+// parentheses, unary minus and the functions registered on it
+type Evaluator struct {
+	funcs map[string]function
+}
+
+func NewEvaluator() *Evaluator {
+	return &Evaluator{funcs: map[string]function{}}
+}
+
+// Register makes fn callable as name(arg1, ..., argN) where N is arity. Registering a
+// name again replaces the function.
+func (e *Evaluator) Register(name string, arity int, fn func(args ...float64) float64) {
+	e.funcs[name] = function{arity, fn}
+}
+
+// RPN is an expression in reverse Polish notation, as returned by Parse
+type RPN []Token
+
+func (r RPN) String() string {
+	parts := make([]string, len(r))
+	for i, t := range r {
+		parts[i] = t.Text
+	}
+	return strings.Join(parts, " ")
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+// tokenize splits the expression into tokens. Operators are all binary here, Parse
+// decides which minus signs are unary.
+func tokenize(expr string) ([]Token, error) {
+	var tokens []Token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+			continue
+		case isDigit(c) || c == '.':
+			for i < len(expr) && (isDigit(expr[i]) || expr[i] == '.') {
+				i++
+			}
+			if i < len(expr) && (expr[i] == 'e' || expr[i] == 'E') {
+				i++
+				if i < len(expr) && (expr[i] == '+' || expr[i] == '-') {
+					i++
+				}
+				for i < len(expr) && isDigit(expr[i]) {
+					i++
+				}
+			}
+			v, err := strconv.ParseFloat(expr[start:i], 64)
+			if err != nil {
+				return nil, errorf(start, "invalid number %q", expr[start:i])
+			}
+			tokens = append(tokens, Token{Kind: Number, Text: expr[start:i], Value: v, Pos: start})
+			continue
+		case isLetter(c):
+			for i < len(expr) && (isLetter(expr[i]) || isDigit(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, Token{Kind: Function, Text: expr[start:i], Pos: start})
+			continue
+		case c == '(':
+			tokens = append(tokens, Token{Kind: LeftParen, Text: "(", Pos: start})
+		case c == ')':
+			tokens = append(tokens, Token{Kind: RightParen, Text: ")", Pos: start})
+		case c == ',':
+			tokens = append(tokens, Token{Kind: Comma, Text: ",", Pos: start})
+		case strings.IndexByte("+-*/%^", c) >= 0:
+			tokens = append(tokens, Token{Kind: Operator, Text: string(c), Pos: start})
+		default:
+			return nil, errorf(start, "unexpected character %q", c)
+		}
+		i++
+	}
+	return tokens, nil
+}
+
+// call tracks an open parenthesis while parsing
+type call struct {
+	fn   bool // whether the parenthesis opens the arguments of a function
+	args int  // commas seen so far
+}
+
+// Parse converts an infix expression to RPN with the shunting-yard algorithm
+func (e *Evaluator) Parse(expr string) (RPN, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var out RPN
+	ops := stack.New[Token]()
+	calls := stack.New[call]()
+	// whether the next token must start an operand, i.e. we're not right after a
+	// number, a closing parenthesis or a function call
+	operand := true
+
+	// popUntilParen moves operators to the output until the innermost open parenthesis
+	popUntilParen := func() bool {
+		for !ops.IsEmpty() {
+			if top, _ := ops.Top(); top.Kind == LeftParen {
+				return true
+			}
+			top, _ := ops.Pop()
+			out = append(out, top)
+		}
+		return false
+	}
+
+	for i, t := range tokens {
+		switch t.Kind {
+		case Number:
+			if !operand {
+				return nil, errorf(t.Pos, "unexpected number %s", t.Text)
+			}
+			out = append(out, t)
+			operand = false
+
+		case Function:
+			if _, ok := e.funcs[t.Text]; !ok {
+				return nil, errorf(t.Pos, "unknown function %s", t.Text)
+			}
+			if !operand {
+				return nil, errorf(t.Pos, "unexpected function %s", t.Text)
+			}
+			if i+1 == len(tokens) || tokens[i+1].Kind != LeftParen {
+				return nil, errorf(t.Pos, "expected ( after function %s", t.Text)
+			}
+			ops.Push(t)
+
+		case LeftParen:
+			if !operand {
+				return nil, errorf(t.Pos, "unexpected (")
+			}
+			calls.Push(call{fn: i > 0 && tokens[i-1].Kind == Function})
+			ops.Push(t)
+
+		case Comma:
+			c, err := calls.Pop()
+			if err != nil || !c.fn {
+				return nil, errorf(t.Pos, "unexpected , outside of a function call")
+			}
+			if operand {
+				return nil, errorf(t.Pos, "missing argument before ,")
+			}
+			popUntilParen()
+			c.args++
+			calls.Push(c)
+			operand = true
+
+		case RightParen:
+			c, err := calls.Pop()
+			if err != nil {
+				return nil, errorf(t.Pos, "unmatched )")
+			}
+			empty := i > 0 && tokens[i-1].Kind == LeftParen
+			if operand && !(c.fn && empty) {
+				return nil, errorf(t.Pos, "unexpected )")
+			}
+			popUntilParen()
+			ops.Pop()
+			if c.fn {
+				fn, _ := ops.Pop()
+				fn.Arity = c.args + 1
+				if empty {
+					fn.Arity = 0
+				}
+				if want := e.funcs[fn.Text].arity; fn.Arity != want {
+					return nil, errorf(fn.Pos, "function %s takes %d arguments, got %d", fn.Text, want, fn.Arity)
+				}
+				out = append(out, fn)
+			}
+			operand = false
+
+		case Operator:
+			if operand {
+				if t.Text == "+" {
+					// unary plus does nothing
+					continue
+				}
+				if t.Text != "-" {
+					return nil, errorf(t.Pos, "unexpected operator %s", t.Text)
+				}
+				t.Text = _neg
+				ops.Push(t)
+				continue
+			}
+			op := operators[t.Text]
+			for !ops.IsEmpty() {
+				top, _ := ops.Top()
+				if top.Kind != Operator {
+					break
+				}
+				prev := operators[top.Text]
+				if prev.precedence < op.precedence || (prev.precedence == op.precedence && op.rightAssoc) {
+					break
+				}
+				ops.Pop()
+				out = append(out, top)
+			}
+			ops.Push(t)
+			operand = true
+		}
+	}
+
+	if operand {
+		return nil, errorf(len(expr), "unexpected end of expression")
+	}
+	for !ops.IsEmpty() {
+		top, _ := ops.Pop()
+		if top.Kind == LeftParen {
+			return nil, errorf(top.Pos, "unclosed (")
+		}
+		out = append(out, top)
+	}
+	return out, nil
+}
+
+// Eval evaluates an expression returned by Parse
+func (e *Evaluator) Eval(rpn RPN) (float64, error) {
+	values := stack.New[float64]()
+	for _, t := range rpn {
+		switch t.Kind {
+		case Number:
+			values.Push(t.Value)
+		case Operator:
+			if t.Text == _neg {
+				x, err := values.Pop()
+				if err != nil {
+					return 0, errorf(t.Pos, "missing operand of unary -")
+				}
+				values.Push(-x)
+				continue
+			}
+			b, errB := values.Pop()
+			a, errA := values.Pop()
+			if errA != nil || errB != nil {
+				return 0, errorf(t.Pos, "missing operand of %s", t.Text)
+			}
+			v, err := apply(t, a, b)
+			if err != nil {
+				return 0, err
+			}
+			values.Push(v)
+		case Function:
+			f, ok := e.funcs[t.Text]
+			if !ok {
+				return 0, errorf(t.Pos, "unknown function %s", t.Text)
+			}
+			if values.Size() < t.Arity {
+				return 0, errorf(t.Pos, "missing arguments of function %s", t.Text)
+			}
+			args := make([]float64, t.Arity)
+			for i := t.Arity - 1; i >= 0; i-- {
+				args[i], _ = values.Pop()
+			}
+			values.Push(f.fn(args...))
+		default:
+			return 0, errorf(t.Pos, "unexpected %s in RPN", t.Text)
+		}
+	}
+	if values.Size() != 1 {
+		return 0, errorf(0, "malformed RPN leaves %d values", values.Size())
+	}
+	return values.Pop()
+}
+
+func apply(t Token, a, b float64) (float64, error) {
+	switch t.Text {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return 0, errorf(t.Pos, "division by zero")
+		}
+		return a / b, nil
+	case "%":
+		if b == 0 {
+			return 0, errorf(t.Pos, "division by zero")
+		}
+		return math.Mod(a, b), nil
+	case "^":
+		return math.Pow(a, b), nil
+	}
+	return 0, errorf(t.Pos, "unknown operator %s", t.Text)
+}
+
+// Evaluate parses and evaluates expr
+func (e *Evaluator) Evaluate(expr string) (float64, error) {
+	rpn, err := e.Parse(expr)
+	if err != nil {
+		return 0, err
+	}
+	return e.Eval(rpn)
+}
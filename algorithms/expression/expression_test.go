@@ -0,0 +1,102 @@
+package expression
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func newTestEvaluator() *Evaluator {
+	e := NewEvaluator()
+	e.Register("max", 2, func(args ...float64) float64 { return math.Max(args[0], args[1]) })
+	e.Register("sqrt", 1, func(args ...float64) float64 { return math.Sqrt(args[0]) })
+	e.Register("pi", 0, func(args ...float64) float64 { return math.Pi })
+	return e
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2 ^ 2", -4},
+		{"(-2) ^ 2", 4},
+		{"-3 * -2", 6},
+		{"2 * -(1 + 2)", -6},
+		{"--1", 1},
+		{"+5 - 1", 4},
+		{"7 % 4", 3},
+		{"1.5e1 / 3", 5},
+		{"max(1, 2 + 3) * 2", 10},
+		{"sqrt(max(9, 16))", 4},
+		{"pi() * 0", 0},
+		{"-max(-1, -2)", 1},
+	}
+
+	e := newTestEvaluator()
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := e.Evaluate(tt.expr)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Evaluate(%q) = %v, want %v", tt.expr, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseRPN(t *testing.T) {
+	e := newTestEvaluator()
+	rpn, err := e.Parse("3 + 4 * max(2, -1) ^ 2")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, want := rpn.String(), "3 4 2 1 neg max 2 ^ * +"; got != want {
+		t.Errorf("RPN = %q, want %q", got, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{"", 0},
+		{"1 +", 3},
+		{"1 2", 2},
+		{"(1 + 2", 0},
+		{"1 + 2)", 5},
+		{"2 * * 3", 4},
+		{"foo(1)", 0},
+		{"max(1)", 0},
+		{"max(1, 2, 3)", 0},
+		{"max 1", 0},
+		{"max(1,)", 6},
+		{"1, 2", 1},
+		{"()", 1},
+		{"1 $ 2", 2},
+		{"1..2", 0},
+		{"1 / (2 - 2)", 2},
+		{"sqrt(1) (2)", 8},
+	}
+
+	e := newTestEvaluator()
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := e.Evaluate(tt.expr)
+			var perr *Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("Expected an *Error, got %v", err)
+			}
+			if perr.Pos != tt.pos {
+				t.Errorf("Error %q at position %d, want %d", perr.Msg, perr.Pos, tt.pos)
+			}
+		})
+	}
+}
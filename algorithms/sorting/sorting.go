@@ -0,0 +1,220 @@
+package sorting
+
+import (
+	"errors"
+	"math/bits"
+
+	alg "github.com/lucasturci/everything-go/algorithms"
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+// All the functions here work on plain slices as well as on vector.Vector[T], and
+// sort in increasing order according to c.
+
+var ErrOutOfBounds = errors.New("index is out of bounds")
+
+// ranges this small are insertion sorted
+const _insertionSortMax = 16
+
+func IsSorted[S ~[]T, T any](s S, c comparator.Comparator[T]) bool {
+	for i := 1; i < len(s); i++ {
+		if c.Less(s[i], s[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Sort is an introsort: a quicksort that switches to heap sort when the recursion
+// gets too deep, so it is O(n log n) in the worst case. It is not stable.
+func Sort[S ~[]T, T any](s S, c comparator.Comparator[T]) {
+	introsort(s, c, 2*bits.Len(uint(len(s))))
+}
+
+func introsort[S ~[]T, T any](s S, c comparator.Comparator[T], depth int) {
+	for len(s) > _insertionSortMax {
+		if depth == 0 {
+			HeapSort(s, c)
+			return
+		}
+		depth--
+		p := partition(s, c)
+		// recurse on the smaller side and loop on the larger one, to bound the stack
+		if p < len(s)-p {
+			introsort(s[:p], c, depth)
+			s = s[p+1:]
+		} else {
+			introsort(s[p+1:], c, depth)
+			s = s[:p]
+		}
+	}
+	insertionSort(s, c)
+}
+
+// partition picks a median of three pivot and moves it to its final position p, with
+// no greater element before it and no smaller element after it. Elements equal to
+// the pivot are spread on both sides, so repeated keys don't degrade it.
+func partition[S ~[]T, T any](s S, c comparator.Comparator[T]) int {
+	n, m := len(s), len(s)/2
+	if c.Less(s[m], s[0]) {
+		alg.Swap(&s[m], &s[0])
+	}
+	if c.Less(s[n-1], s[0]) {
+		alg.Swap(&s[n-1], &s[0])
+	}
+	if c.Less(s[n-1], s[m]) {
+		alg.Swap(&s[n-1], &s[m])
+	}
+	alg.Swap(&s[0], &s[m])
+
+	pivot := s[0]
+	i, j := 1, n-1
+	for {
+		for i <= j && c.Less(s[i], pivot) {
+			i++
+		}
+		for i <= j && c.Less(pivot, s[j]) {
+			j--
+		}
+		if i >= j {
+			break
+		}
+		alg.Swap(&s[i], &s[j])
+		i++
+		j--
+	}
+	alg.Swap(&s[0], &s[j])
+	return j
+}
+
+func insertionSort[S ~[]T, T any](s S, c comparator.Comparator[T]) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && c.Less(s[j], s[j-1]); j-- {
+			alg.Swap(&s[j], &s[j-1])
+		}
+	}
+}
+
+// MergeSort is a stable sort: equal elements keep their relative order. It uses
+// O(n) extra memory.
+func MergeSort[S ~[]T, T any](s S, c comparator.Comparator[T]) {
+	mergeSort(s, make(S, len(s)), c)
+}
+
+func mergeSort[S ~[]T, T any](s, buf S, c comparator.Comparator[T]) {
+	if len(s) <= _insertionSortMax {
+		insertionSort(s, c)
+		return
+	}
+	m := len(s) / 2
+	mergeSort(s[:m], buf[:m], c)
+	mergeSort(s[m:], buf[m:], c)
+	if !c.Less(s[m], s[m-1]) { // already in order
+		return
+	}
+
+	// merge the left half, moved to buf, with the right half that is still in place.
+	// The write position never gets past the right half's read position.
+	left := buf[:m]
+	copy(left, s[:m])
+	i, j, k := 0, m, 0
+	for i < len(left) && j < len(s) {
+		if c.Less(s[j], left[i]) { // take from the left on ties, for stability
+			s[k] = s[j]
+			j++
+		} else {
+			s[k] = left[i]
+			i++
+		}
+		k++
+	}
+	copy(s[k:], left[i:])
+}
+
+// siftDown moves s[i] down the max heap s until neither child is greater than it
+func siftDown[S ~[]T, T any](s S, i int, c comparator.Comparator[T]) {
+	for {
+		largest := i
+		for _, child := range [2]int{2*i + 1, 2*i + 2} {
+			if child < len(s) && c.Less(s[largest], s[child]) {
+				largest = child
+			}
+		}
+		if largest == i {
+			return
+		}
+		alg.Swap(&s[i], &s[largest])
+		i = largest
+	}
+}
+
+// buildMaxHeap arranges s so that every element is at least as great as its children
+func buildMaxHeap[S ~[]T, T any](s S, c comparator.Comparator[T]) {
+	for i := len(s)/2 - 1; i >= 0; i-- {
+		siftDown(s, i, c)
+	}
+}
+
+// sortMaxHeap sorts the max heap s by moving its greatest element to the end and
+// shrinking the heap
+func sortMaxHeap[S ~[]T, T any](s S, c comparator.Comparator[T]) {
+	for end := len(s) - 1; end > 0; end-- {
+		alg.Swap(&s[0], &s[end])
+		siftDown(s[:end], 0, c)
+	}
+}
+
+// HeapSort sorts in place in O(n log n) time. It is not stable.
+func HeapSort[S ~[]T, T any](s S, c comparator.Comparator[T]) {
+	buildMaxHeap(s, c)
+	sortMaxHeap(s, c)
+}
+
+// PartialSort puts the k smallest elements of s, sorted, in s[:k]. The order of the
+// rest of s is unspecified. It takes O(n log k) time.
+func PartialSort[S ~[]T, T any](s S, k int, c comparator.Comparator[T]) error {
+	if k < 0 || k > len(s) {
+		return ErrOutOfBounds
+	}
+	if k == 0 {
+		return nil
+	}
+	top := s[:k]
+	buildMaxHeap(top, c)
+	for i := k; i < len(s); i++ {
+		if c.Less(s[i], top[0]) {
+			alg.Swap(&top[0], &s[i])
+			siftDown(top, 0, c)
+		}
+	}
+	sortMaxHeap(top, c)
+	return nil
+}
+
+// NthElement rearranges s so that s[k] is the element that would be there if s was
+// sorted, with no greater element before it and no smaller element after it. It is a
+// quickselect, taking O(n) time on average.
+func NthElement[S ~[]T, T any](s S, k int, c comparator.Comparator[T]) error {
+	if k < 0 || k >= len(s) {
+		return ErrOutOfBounds
+	}
+	depth := 2 * bits.Len(uint(len(s)))
+	for len(s) > _insertionSortMax {
+		if depth == 0 {
+			HeapSort(s, c)
+			return nil
+		}
+		depth--
+		p := partition(s, c)
+		if k == p {
+			return nil
+		} else if k < p {
+			s = s[:p]
+		} else {
+			s = s[p+1:]
+			k -= p + 1
+		}
+	}
+	insertionSort(s, c)
+	return nil
+}
@@ -0,0 +1,190 @@
+package sorting
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+	"github.com/lucasturci/everything-go/data-structures/tuple"
+	"github.com/lucasturci/everything-go/data-structures/vector"
+)
+
+func randomInts(n, maxVal int, r *rand.Rand) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = r.Intn(maxVal)
+	}
+	return s
+}
+
+// inputs covers random data, many repeated keys and already (reverse) sorted data
+func inputs(r *rand.Rand) map[string][]int {
+	ans := map[string][]int{
+		"empty":  {},
+		"single": {1},
+	}
+	for _, n := range []int{10, 100, 1000} {
+		random := randomInts(n, 1<<30, r)
+		sorted := slices.Sorted(slices.Values(random))
+		reversed := slices.Clone(sorted)
+		slices.Reverse(reversed)
+		ans[fmt.Sprintf("random %d", n)] = random
+		ans[fmt.Sprintf("repeated %d", n)] = randomInts(n, 3, r)
+		ans[fmt.Sprintf("sorted %d", n)] = sorted
+		ans[fmt.Sprintf("reversed %d", n)] = reversed
+	}
+	return ans
+}
+
+func TestSorts(t *testing.T) {
+	sorts := []struct {
+		name string
+		fn   func([]int, comparator.Comparator[int])
+	}{
+		{"introsort", Sort[[]int]},
+		{"merge sort", MergeSort[[]int]},
+		{"heap sort", HeapSort[[]int]},
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for name, input := range inputs(r) {
+		want := slices.Sorted(slices.Values(input))
+		for _, sort := range sorts {
+			t.Run(sort.name+" "+name, func(t *testing.T) {
+				got := slices.Clone(input)
+				sort.fn(got, comparator.Less[int]{})
+				if !slices.Equal(got, want) {
+					t.Errorf("Result is not sorted: %v", got)
+				}
+				if !IsSorted(got, comparator.Less[int]{}) {
+					t.Error("IsSorted returned false on sorted data")
+				}
+			})
+		}
+	}
+}
+
+func TestSortVector(t *testing.T) {
+	v := vector.NewWithElements([]int{3, 1, 2})
+	Sort(v, comparator.Greater[int]{})
+	if !slices.Equal(v, vector.Vector[int]{3, 2, 1}) {
+		t.Errorf("Expected [3 2 1], got %v", v)
+	}
+	if IsSorted(v, comparator.Less[int]{}) {
+		t.Error("IsSorted returned true on unsorted data")
+	}
+}
+
+func TestMergeSortIsStable(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	s := make([]tuple.Pair[int, int], 1000)
+	for i := range s {
+		s[i] = tuple.Pair[int, int]{First: r.Intn(10), Second: i}
+	}
+	byFirst := comparator.Custom(func(a, b tuple.Pair[int, int]) bool { return a.First < b.First })
+
+	got := slices.Clone(s)
+	MergeSort(got, byFirst)
+	slices.SortStableFunc(s, func(a, b tuple.Pair[int, int]) int { return cmp.Compare(a.First, b.First) })
+	if !slices.Equal(got, s) {
+		t.Error("MergeSort did not keep the order of equal elements")
+	}
+}
+
+func TestPartialSort(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	input := randomInts(500, 100, r)
+	want := slices.Sorted(slices.Values(input))
+	for _, k := range []int{0, 1, 10, 499, 500} {
+		got := slices.Clone(input)
+		if err := PartialSort(got, k, comparator.Less[int]{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !slices.Equal(got[:k], want[:k]) {
+			t.Errorf("PartialSort(%d): prefix %v, want %v", k, got[:k], want[:k])
+		}
+		if !slices.Equal(slices.Sorted(slices.Values(got)), want) {
+			t.Errorf("PartialSort(%d) lost elements", k)
+		}
+	}
+
+	if err := PartialSort(input, 501, comparator.Less[int]{}); err != ErrOutOfBounds {
+		t.Errorf("Expected ErrOutOfBounds, got %v", err)
+	}
+}
+
+func TestNthElement(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for name, input := range inputs(r) {
+		if len(input) == 0 {
+			continue
+		}
+		want := slices.Sorted(slices.Values(input))
+		for _, k := range []int{0, len(input) / 3, len(input) - 1} {
+			got := slices.Clone(input)
+			if err := NthElement(got, k, comparator.Less[int]{}); err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+			if got[k] != want[k] {
+				t.Errorf("%s: NthElement(%d) = %d, want %d", name, k, got[k], want[k])
+			}
+			for i := range got {
+				if (i < k && got[i] > got[k]) || (i > k && got[i] < got[k]) {
+					t.Errorf("%s: element %d is on the wrong side of %d", name, got[i], k)
+					break
+				}
+			}
+		}
+	}
+
+	if err := NthElement([]int{}, 0, comparator.Less[int]{}); err != ErrOutOfBounds {
+		t.Errorf("Expected ErrOutOfBounds, got %v", err)
+	}
+}
+
+// Benchmarks
+
+const _benchSize = 100000
+
+func benchmarkSort(b *testing.B, sort func([]int)) {
+	input := randomInts(_benchSize, 1<<30, rand.New(rand.NewSource(5)))
+	s := make([]int, len(input))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(s, input)
+		b.StartTimer()
+		sort(s)
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	benchmarkSort(b, func(s []int) { Sort(s, comparator.Less[int]{}) })
+}
+
+func BenchmarkMergeSort(b *testing.B) {
+	benchmarkSort(b, func(s []int) { MergeSort(s, comparator.Less[int]{}) })
+}
+
+func BenchmarkHeapSort(b *testing.B) {
+	benchmarkSort(b, func(s []int) { HeapSort(s, comparator.Less[int]{}) })
+}
+
+func BenchmarkPartialSort(b *testing.B) {
+	benchmarkSort(b, func(s []int) { PartialSort(s, 100, comparator.Less[int]{}) })
+}
+
+func BenchmarkNthElement(b *testing.B) {
+	benchmarkSort(b, func(s []int) { NthElement(s, len(s)/2, comparator.Less[int]{}) })
+}
+
+func BenchmarkSlicesSortFunc(b *testing.B) {
+	benchmarkSort(b, func(s []int) { slices.SortFunc(s, cmp.Compare[int]) })
+}
+
+func BenchmarkSlicesSortStableFunc(b *testing.B) {
+	benchmarkSort(b, func(s []int) { slices.SortStableFunc(s, cmp.Compare[int]) })
+}
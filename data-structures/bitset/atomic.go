@@ -0,0 +1,145 @@
+package bitset
+
+import (
+	"iter"
+	"math/bits"
+	"sync/atomic"
+)
+
+// AtomicBitset is a fixed size bitset that is safe for concurrent use without locks.
+// Every operation on a single bit is atomic. Operations on the whole set, like Count,
+// read each word atomically but are not a snapshot of the set at a single point in time.
+type AtomicBitset struct {
+	set []atomic.Uint64
+	n   int
+}
+
+var _ Set = &AtomicBitset{}
+
+func NewAtomic(n int) *AtomicBitset {
+	return &AtomicBitset{
+		set: make([]atomic.Uint64, words(n)),
+		n:   n,
+	}
+}
+
+func (b *AtomicBitset) Size() int {
+	return b.n
+}
+
+func (b *AtomicBitset) Set(p int) error {
+	_, err := b.TestAndSet(p)
+	return err
+}
+
+// TestAndSet sets the bit at p and returns whether it was already set. Among
+// goroutines racing to set the same bit, exactly one sees false.
+func (b *AtomicBitset) TestAndSet(p int) (bool, error) {
+	if p < 0 || p >= b.n {
+		return false, outOfBounds(p, b.n)
+	}
+	mask := uint64(1) << (p % _byteSize)
+	old := b.set[p/_byteSize].Or(mask)
+	return old&mask != 0, nil
+}
+
+func (b *AtomicBitset) Clear(p int) error {
+	_, err := b.TestAndClear(p)
+	return err
+}
+
+// TestAndClear clears the bit at p and returns whether it was set
+func (b *AtomicBitset) TestAndClear(p int) (bool, error) {
+	if p < 0 || p >= b.n {
+		return false, outOfBounds(p, b.n)
+	}
+	mask := uint64(1) << (p % _byteSize)
+	old := b.set[p/_byteSize].And(^mask)
+	return old&mask != 0, nil
+}
+
+func (b *AtomicBitset) Get(p int) (bool, error) {
+	if p < 0 || p >= b.n {
+		return false, outOfBounds(p, b.n)
+	}
+	return (b.set[p/_byteSize].Load()>>(p%_byteSize))&1 == 1, nil
+}
+
+func (b *AtomicBitset) Count() int {
+	ans := 0
+	for i := range b.set {
+		ans += int(popcount(b.set[i].Load()))
+	}
+	return ans
+}
+
+func (b *AtomicBitset) Ones() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := range b.set {
+			word := b.set[i].Load()
+			for word != 0 {
+				if !yield(i*_byteSize + bits.TrailingZeros64(word)) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// UnionWith sets every bit of b that is set in o, one word at a time. Bits of o past
+// the size of b are ignored.
+func (b *AtomicBitset) UnionWith(o Set) {
+	for i := range b.set {
+		if w := word(o, i) & b.valid(i); w != 0 {
+			b.set[i].Or(w)
+		}
+	}
+}
+
+// IntersectionWith clears every bit of b that is not set in o, one word at a time
+func (b *AtomicBitset) IntersectionWith(o Set) {
+	for i := range b.set {
+		b.set[i].And(word(o, i))
+	}
+}
+
+// valid returns the mask of the bits of the i-th word that lie inside the bitset
+func (b *AtomicBitset) valid(i int) uint64 {
+	if r := b.n % _byteSize; r != 0 && i == len(b.set)-1 {
+		return (1 << r) - 1
+	}
+	return ^uint64(0)
+}
+
+// word returns the i-th word of o, which is zero past the end of o
+func word(o Set, i int) uint64 {
+	switch x := o.(type) {
+	case *AtomicBitset:
+		if i < len(x.set) {
+			return x.set[i].Load()
+		}
+	case *Bitset:
+		if i < len(x.set) {
+			return x.set[i]
+		}
+	default:
+		var w uint64
+		for j := 0; j < _byteSize; j++ {
+			if in, _ := o.Get(i*_byteSize + j); in {
+				w |= 1 << j
+			}
+		}
+		return w
+	}
+	return 0
+}
+
+// Snapshot copies the bits into a regular Bitset
+func (b *AtomicBitset) Snapshot() Bitset {
+	ans := New(b.n)
+	for i := range b.set {
+		ans.set[i] = b.set[i].Load()
+	}
+	return ans
+}
@@ -0,0 +1,102 @@
+package bitset
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAtomicBasicOperations(t *testing.T) {
+	b := NewAtomic(130)
+	if was, _ := b.TestAndSet(64); was {
+		t.Error("Expected bit 64 to not be set before TestAndSet")
+	}
+	if was, _ := b.TestAndSet(64); !was {
+		t.Error("Expected bit 64 to be set on second TestAndSet")
+	}
+	b.Set(129)
+	if got, _ := b.Get(129); !got {
+		t.Error("Expected bit 129 to be set")
+	}
+	if b.Count() != 2 {
+		t.Errorf("Expected count 2, got %d", b.Count())
+	}
+
+	b.Clear(64)
+	if got, _ := b.Get(64); got {
+		t.Error("Expected bit 64 to be cleared")
+	}
+	if !b.Snapshot().Equal(fromBits(130, 129)) {
+		t.Errorf("Unexpected snapshot %v", b.Snapshot())
+	}
+
+	for _, p := range []int{-1, 130} {
+		if err := b.Set(p); err == nil {
+			t.Errorf("Expected error on Set(%d)", p)
+		}
+		if _, err := b.Get(p); err == nil {
+			t.Errorf("Expected error on Get(%d)", p)
+		}
+	}
+}
+
+// Run with -race. Every worker tries to claim every bit, so all the workers hammer
+// the same words at the same time.
+func TestAtomicNoLostUpdates(t *testing.T) {
+	const (
+		n       = 10000
+		workers = 8
+	)
+	b := NewAtomic(n)
+	var claimed atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := 0; p < n; p++ {
+				if was, _ := b.TestAndSet((p + w*n/workers) % n); !was {
+					claimed.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if claimed.Load() != n {
+		t.Errorf("Expected exactly %d successful claims, got %d", n, claimed.Load())
+	}
+	if b.Count() != n {
+		t.Errorf("Expected count %d, got %d", n, b.Count())
+	}
+
+	// half the workers clear the even bits while the other half set the odd ones
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := w % 2; p < n; p += 2 {
+				if w%2 == 0 {
+					b.Clear(p)
+				} else {
+					b.Set(p)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if b.Count() != n/2 {
+		t.Errorf("Expected count %d, got %d", n/2, b.Count())
+	}
+}
+
+func TestAtomicUnionWithLarger(t *testing.T) {
+	b := NewAtomic(100)
+	o := fromBits(130, 3, 110, 129)
+	b.UnionWith(&o)
+	if got := slices.Collect(b.Ones()); !slices.Equal(got, []int{3}) {
+		t.Errorf("Expected the bits past the size to be ignored, got %v", got)
+	}
+}
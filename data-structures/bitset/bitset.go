@@ -1,38 +1,133 @@
 package bitset
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// Set is the common interface of *Bitset and *Roaring, so callers can swap them. The
+// in-place operations accept any Set, and are fastest when both operands have the
+// same type.
+type Set interface {
+	Size() int
+	Set(p int) error
+	Clear(p int) error
+	Get(p int) (bool, error)
+	Count() int
+	// Ones yields the set bits in increasing order
+	Ones() iter.Seq[int]
+	UnionWith(o Set)
+	IntersectionWith(o Set)
+}
 
 type Bitset struct {
-	set []uint64
-	n   int
+	set      []uint64
+	n        int
+	growable bool
 }
 
+var _ Set = &Bitset{}
+
 const _byteSize = 64
 
 func New(n int) Bitset {
 	return Bitset{
-		set: make([]uint64, (n+_byteSize-1)/_byteSize),
+		set: make([]uint64, words(n)),
 		n:   n,
 	}
 }
 
+// NewGrowable returns a bitset of size n that grows when a bit past its end is set
+func NewGrowable(n int) Bitset {
+	b := New(n)
+	b.growable = true
+	return b
+}
+
+func words(n int) int {
+	return (n + _byteSize - 1) / _byteSize
+}
+
+func outOfBounds(p, n int) error {
+	return fmt.Errorf("out of bounds access: trying to access %v at bitset of length %v", p, n)
+}
+
 func (b Bitset) Size() int {
 	return b.n
 }
 
-func (b Bitset) Set(p int) error {
-	if p < 0 || p > b.n {
-		return fmt.Errorf("out of bounds access: trying to access %v at bitset of length %v", p, b.n)
+func (b Bitset) IsGrowable() bool {
+	return b.growable
+}
+
+func (b Bitset) Clone() Bitset {
+	ans := b
+	ans.set = make([]uint64, len(b.set))
+	copy(ans.set, b.set)
+	return ans
+}
+
+// grow makes the bitset at least n bits long. Bits past the old size are always
+// kept cleared, so they come out as zeroes.
+func (b *Bitset) grow(n int) {
+	if n <= b.n {
+		return
+	}
+	for len(b.set) < words(n) {
+		b.set = append(b.set, 0)
 	}
+	b.n = n
+}
+
+// trim clears the bits of the last word that lie past the size of the bitset
+func (b *Bitset) trim() {
+	if r := b.n % _byteSize; r != 0 {
+		b.set[len(b.set)-1] &= (1 << r) - 1
+	}
+}
+
+func (b *Bitset) Set(p int) error {
+	if p < 0 || (p >= b.n && !b.growable) {
+		return outOfBounds(p, b.n)
+	}
+	b.grow(p + 1)
 	i := p / _byteSize
 	j := p % _byteSize
 	b.set[i] |= (1 << j)
 	return nil
 }
 
+func (b *Bitset) Clear(p int) error {
+	if p < 0 || p >= b.n {
+		if p >= b.n && b.growable { // bits past the end are already cleared
+			return nil
+		}
+		return outOfBounds(p, b.n)
+	}
+	i := p / _byteSize
+	j := p % _byteSize
+	b.set[i] &^= (1 << j)
+	return nil
+}
+
+func (b *Bitset) Flip(p int) error {
+	if p < 0 || (p >= b.n && !b.growable) {
+		return outOfBounds(p, b.n)
+	}
+	b.grow(p + 1)
+	i := p / _byteSize
+	j := p % _byteSize
+	b.set[i] ^= (1 << j)
+	return nil
+}
+
 func (b Bitset) Get(p int) (bool, error) {
-	if p < 0 || p > b.n {
-		return false, fmt.Errorf("out of bounds access: trying to access %v at bitset of length %v", p, b.n)
+	if p < 0 || p >= b.n {
+		if p >= b.n && b.growable {
+			return false, nil
+		}
+		return false, outOfBounds(p, b.n)
 	}
 	i := p / _byteSize
 	j := p % _byteSize
@@ -43,6 +138,11 @@ func (b Bitset) Get(p int) (bool, error) {
 	return false, nil
 }
 
+// Reset clears every bit, keeping the size
+func (b *Bitset) Reset() {
+	clear(b.set)
+}
+
 func (b Bitset) Count() int {
 	ans := 0
 	for i := 0; i < len(b.set); i++ {
@@ -51,22 +151,358 @@ func (b Bitset) Count() int {
 	return ans
 }
 
-func Union(x, y Bitset) Bitset {
+func (b Bitset) Any() bool {
+	for _, w := range b.set {
+		if w != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (b Bitset) None() bool {
+	return !b.Any()
+}
+
+func (b Bitset) All() bool {
+	return b.Count() == b.n
+}
+
+// Equal reports whether both bitsets have the same size and the same bits set
+func (b Bitset) Equal(o Bitset) bool {
+	if b.n != o.n {
+		return false
+	}
+	for i := range b.set {
+		if b.set[i] != o.set[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// In-place operations. The receiver keeps its size unless it is growable, in which
+// case it grows to fit the other operand. Bits of o past the size of b are ignored.
+
+func (b *Bitset) UnionWith(o Set) {
+	if b.growable {
+		b.grow(o.Size())
+	}
+	x, ok := o.(*Bitset)
+	if !ok {
+		for p := range o.Ones() {
+			if p >= b.n {
+				break
+			}
+			b.Set(p)
+		}
+		return
+	}
+	for i := 0; i < min(len(b.set), len(x.set)); i++ {
+		b.set[i] |= x.set[i]
+	}
+	b.trim()
+}
+
+func (b *Bitset) IntersectionWith(o Set) {
+	x, ok := o.(*Bitset)
+	if !ok {
+		for p := range b.Ones() {
+			if in, _ := o.Get(p); !in {
+				b.Clear(p)
+			}
+		}
+		return
+	}
+	for i := 0; i < len(b.set); i++ {
+		if i < len(x.set) {
+			b.set[i] &= x.set[i]
+		} else {
+			b.set[i] = 0
+		}
+	}
+}
+
+func (b *Bitset) XorWith(o Bitset) {
+	if b.growable {
+		b.grow(o.n)
+	}
+	for i := 0; i < min(len(b.set), len(o.set)); i++ {
+		b.set[i] ^= o.set[i]
+	}
+	b.trim()
+}
+
+// AndNot clears every bit of b that is set in o
+func (b *Bitset) AndNot(o Bitset) {
+	for i := 0; i < min(len(b.set), len(o.set)); i++ {
+		b.set[i] &^= o.set[i]
+	}
+}
+
+// Not flips every bit of b in place
+func (b *Bitset) Not() {
+	for i := range b.set {
+		b.set[i] = ^b.set[i]
+	}
+	b.trim()
+}
+
+// Range operations work on the half-open interval [l, r) a word at a time
+
+func (b Bitset) checkRange(l, r int) error {
+	if l < 0 || r > b.n || l > r {
+		return fmt.Errorf("invalid range: trying to access [%v, %v) at bitset of length %v", l, r, b.n)
+	}
+	return nil
+}
+
+// applyRange calls op for every word that intersects [l, r), along with the mask of
+// the bits of that word that lie inside the range
+func (b Bitset) applyRange(l, r int, op func(w *uint64, mask uint64)) {
+	if l == r {
+		return
+	}
+	wl, wr := l/_byteSize, (r-1)/_byteSize
+	first := ^uint64(0) << (l % _byteSize)
+	last := ^uint64(0) >> (_byteSize - 1 - (r-1)%_byteSize)
+	if wl == wr {
+		op(&b.set[wl], first&last)
+		return
+	}
+	op(&b.set[wl], first)
+	for i := wl + 1; i < wr; i++ {
+		op(&b.set[i], ^uint64(0))
+	}
+	op(&b.set[wr], last)
+}
+
+func (b *Bitset) SetRange(l, r int) error {
+	if b.growable && l >= 0 && l <= r {
+		b.grow(r)
+	}
+	if err := b.checkRange(l, r); err != nil {
+		return err
+	}
+	b.applyRange(l, r, func(w *uint64, mask uint64) { *w |= mask })
+	return nil
+}
+
+func (b *Bitset) ClearRange(l, r int) error {
+	if b.growable && l >= 0 && l <= r { // bits past the end are already cleared
+		r = min(r, b.n)
+		l = min(l, r)
+	}
+	if err := b.checkRange(l, r); err != nil {
+		return err
+	}
+	b.applyRange(l, r, func(w *uint64, mask uint64) { *w &^= mask })
+	return nil
+}
+
+func (b *Bitset) FlipRange(l, r int) error {
+	if b.growable && l >= 0 && l <= r {
+		b.grow(r)
+	}
+	if err := b.checkRange(l, r); err != nil {
+		return err
+	}
+	b.applyRange(l, r, func(w *uint64, mask uint64) { *w ^= mask })
+	return nil
+}
+
+func (b Bitset) CountRange(l, r int) (int, error) {
+	if err := b.checkRange(l, r); err != nil {
+		return 0, err
+	}
+	ans := 0
+	b.applyRange(l, r, func(w *uint64, mask uint64) { ans += int(popcount(*w & mask)) })
+	return ans, nil
+}
+
+// Shifts keep the size of the bitset, growable or not: bits shifted past either end
+// are dropped, so shifting by the size or more in either direction clears every bit.
+// ShiftLeft moves bit i to i+k, so dp |= dp << w is written as
+//
+//	s := dp.Clone()
+//	s.ShiftLeft(w)
+//	dp.UnionWith(&s)
+func (b *Bitset) ShiftLeft(k int) {
+	// clearing first also keeps -k from overflowing for math.MinInt
+	if k >= b.n || k <= -b.n {
+		b.Reset()
+		return
+	}
+	if k < 0 {
+		b.ShiftRight(-k)
+		return
+	}
+	ws, bs := k/_byteSize, k%_byteSize
+	for i := len(b.set) - 1; i >= 0; i-- {
+		var v uint64
+		if src := i - ws; src >= 0 {
+			v = b.set[src] << bs
+			if bs > 0 && src > 0 {
+				v |= b.set[src-1] >> (_byteSize - bs)
+			}
+		}
+		b.set[i] = v
+	}
+	b.trim()
+}
+
+// ShiftRight moves bit i to i-k
+func (b *Bitset) ShiftRight(k int) {
+	// clearing first also keeps -k from overflowing for math.MinInt
+	if k >= b.n || k <= -b.n {
+		b.Reset()
+		return
+	}
+	if k < 0 {
+		b.ShiftLeft(-k)
+		return
+	}
+	ws, bs := k/_byteSize, k%_byteSize
+	for i := 0; i < len(b.set); i++ {
+		var v uint64
+		if src := i + ws; src < len(b.set) {
+			v = b.set[src] >> bs
+			if bs > 0 && src+1 < len(b.set) {
+				v |= b.set[src+1] << (_byteSize - bs)
+			}
+		}
+		b.set[i] = v
+	}
+}
+
+// Functions. The result has the size of the largest operand.
+
+func combine(x, y Bitset, op func(a, b uint64) uint64) Bitset {
 	ans := New(max(x.n, y.n))
+	ans.growable = x.growable && y.growable
 	for i := 0; i < len(ans.set); i++ {
-		ans.set[i] = x.set[i] | y.set[i]
+		var a, b uint64
+		if i < len(x.set) {
+			a = x.set[i]
+		}
+		if i < len(y.set) {
+			b = y.set[i]
+		}
+		ans.set[i] = op(a, b)
 	}
 	return ans
 }
 
+func Union(x, y Bitset) Bitset {
+	return combine(x, y, func(a, b uint64) uint64 { return a | b })
+}
+
 func Intersection(x, y Bitset) Bitset {
-	ans := New(max(x.n, y.n))
-	for i := 0; i < len(ans.set); i++ {
-		ans.set[i] = x.set[i] & y.set[i]
-	}
+	return combine(x, y, func(a, b uint64) uint64 { return a & b })
+}
+
+func Xor(x, y Bitset) Bitset {
+	return combine(x, y, func(a, b uint64) uint64 { return a ^ b })
+}
+
+// Difference returns the bits set in x but not in y
+func Difference(x, y Bitset) Bitset {
+	return combine(x, y, func(a, b uint64) uint64 { return a &^ b })
+}
+
+// Complement returns a new bitset with every bit of x flipped
+func Complement(x Bitset) Bitset {
+	ans := x.Clone()
+	ans.Not()
 	return ans
 }
 
+// Scanning. The search functions return false when there is no such bit.
+
+// NextSet returns the first set bit at a position >= i
+func (b Bitset) NextSet(i int) (int, bool) {
+	i = max(i, 0)
+	if i >= b.n {
+		return 0, false
+	}
+	w := i / _byteSize
+	cur := b.set[w] >> (i % _byteSize)
+	if cur != 0 {
+		return i + bits.TrailingZeros64(cur), true
+	}
+	for w++; w < len(b.set); w++ {
+		if b.set[w] != 0 {
+			return w*_byteSize + bits.TrailingZeros64(b.set[w]), true
+		}
+	}
+	return 0, false
+}
+
+// PrevSet returns the last set bit at a position <= i
+func (b Bitset) PrevSet(i int) (int, bool) {
+	i = min(i, b.n-1)
+	if i < 0 {
+		return 0, false
+	}
+	w := i / _byteSize
+	cur := b.set[w] << (_byteSize - 1 - i%_byteSize)
+	if cur != 0 {
+		return i - bits.LeadingZeros64(cur), true
+	}
+	for w--; w >= 0; w-- {
+		if b.set[w] != 0 {
+			return w*_byteSize + _byteSize - 1 - bits.LeadingZeros64(b.set[w]), true
+		}
+	}
+	return 0, false
+}
+
+// NextClear returns the first cleared bit at a position >= i
+func (b Bitset) NextClear(i int) (int, bool) {
+	i = max(i, 0)
+	if i >= b.n {
+		return 0, false
+	}
+	w := i / _byteSize
+	cur := ^b.set[w] >> (i % _byteSize)
+	if cur != 0 {
+		i += bits.TrailingZeros64(cur)
+		return i, i < b.n
+	}
+	for w++; w < len(b.set); w++ {
+		if b.set[w] != ^uint64(0) {
+			i = w*_byteSize + bits.TrailingZeros64(^b.set[w])
+			return i, i < b.n
+		}
+	}
+	return 0, false
+}
+
+func (b Bitset) FirstSet() (int, bool) {
+	return b.NextSet(0)
+}
+
+func (b Bitset) LastSet() (int, bool) {
+	return b.PrevSet(b.n - 1)
+}
+
+// Iterations
+
+// Ones yields the positions of the set bits in increasing order
+func (b Bitset) Ones() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for w, word := range b.set {
+			for word != 0 {
+				j := bits.TrailingZeros64(word)
+				if !yield(w*_byteSize + j) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
 // Taken from https://github.com/tmthrgd/go-popcount/blob/afb1ace8b04f/popcount.go
 func popcount(x uint64) uint64 {
 	x = (x & 0x5555555555555555) + ((x & 0xAAAAAAAAAAAAAAAA) >> 1)
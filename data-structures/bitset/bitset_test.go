@@ -0,0 +1,435 @@
+package bitset
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func fromBits(n int, bits ...int) Bitset {
+	b := New(n)
+	for _, p := range bits {
+		b.Set(p)
+	}
+	return b
+}
+
+func TestSetGetClearFlip(t *testing.T) {
+	b := New(130)
+
+	for _, p := range []int{0, 63, 64, 129} {
+		if err := b.Set(p); err != nil {
+			t.Errorf("Unexpected error on Set(%d): %v", p, err)
+		}
+		if got, _ := b.Get(p); !got {
+			t.Errorf("Expected bit %d to be set", p)
+		}
+	}
+	if b.Count() != 4 {
+		t.Errorf("Expected count 4, got %d", b.Count())
+	}
+
+	b.Clear(63)
+	if got, _ := b.Get(63); got {
+		t.Error("Expected bit 63 to be cleared")
+	}
+
+	b.Flip(63)
+	b.Flip(0)
+	if got, _ := b.Get(63); !got {
+		t.Error("Expected bit 63 to be set after flip")
+	}
+	if got, _ := b.Get(0); got {
+		t.Error("Expected bit 0 to be cleared after flip")
+	}
+
+	b.Reset()
+	if !b.None() {
+		t.Error("Expected no bits set after Reset")
+	}
+	if b.Size() != 130 {
+		t.Errorf("Expected size to remain 130, got %d", b.Size())
+	}
+}
+
+func TestOutOfBounds(t *testing.T) {
+	b := New(64)
+	for _, p := range []int{-1, 64, 100} {
+		if err := b.Set(p); err == nil {
+			t.Errorf("Expected error on Set(%d)", p)
+		}
+		if _, err := b.Get(p); err == nil {
+			t.Errorf("Expected error on Get(%d)", p)
+		}
+		if err := b.Clear(p); err == nil {
+			t.Errorf("Expected error on Clear(%d)", p)
+		}
+		if err := b.Flip(p); err == nil {
+			t.Errorf("Expected error on Flip(%d)", p)
+		}
+	}
+}
+
+func TestAnyAllNone(t *testing.T) {
+	tests := []struct {
+		name           string
+		b              Bitset
+		any, all, none bool
+	}{
+		{"empty", New(10), false, false, true},
+		{"some", fromBits(10, 3), true, false, false},
+		{"full", fromBits(3, 0, 1, 2), true, true, false},
+		{"zero size", New(0), false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.Any(); got != tt.any {
+				t.Errorf("Any() = %v, want %v", got, tt.any)
+			}
+			if got := tt.b.All(); got != tt.all {
+				t.Errorf("All() = %v, want %v", got, tt.all)
+			}
+			if got := tt.b.None(); got != tt.none {
+				t.Errorf("None() = %v, want %v", got, tt.none)
+			}
+		})
+	}
+}
+
+func TestNotKeepsTailMasked(t *testing.T) {
+	b := fromBits(70, 1, 69)
+	b.Not()
+	if b.Count() != 68 {
+		t.Errorf("Expected count 68 after Not, got %d", b.Count())
+	}
+	if !Complement(New(70)).All() {
+		t.Error("Expected complement of empty bitset to have all bits set")
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	x := fromBits(100, 1, 2, 70)
+	y := fromBits(130, 2, 3, 129)
+
+	tests := []struct {
+		name     string
+		got      Bitset
+		expected Bitset
+	}{
+		{"union", Union(x, y), fromBits(130, 1, 2, 3, 70, 129)},
+		{"intersection", Intersection(x, y), fromBits(130, 2)},
+		{"xor", Xor(x, y), fromBits(130, 1, 3, 70, 129)},
+		{"difference", Difference(x, y), fromBits(130, 1, 70)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.expected) {
+				t.Errorf("Result %v differs from expected %v", tt.got.set, tt.expected.set)
+			}
+		})
+	}
+}
+
+func TestInPlaceOperations(t *testing.T) {
+	b := fromBits(100, 1, 2, 70)
+	o := fromBits(130, 3, 129)
+	b.UnionWith(&o)
+	if !b.Equal(fromBits(100, 1, 2, 3, 70)) {
+		t.Errorf("UnionWith: unexpected result %v", b.set)
+	}
+
+	b.XorWith(fromBits(100, 1, 4))
+	if !b.Equal(fromBits(100, 2, 3, 4, 70)) {
+		t.Errorf("XorWith: unexpected result %v", b.set)
+	}
+
+	b.AndNot(fromBits(10, 2, 3))
+	if !b.Equal(fromBits(100, 4, 70)) {
+		t.Errorf("AndNot: unexpected result %v", b.set)
+	}
+
+	o = fromBits(10, 4, 5)
+	b.IntersectionWith(&o)
+	if !b.Equal(fromBits(100, 4)) {
+		t.Errorf("IntersectionWith: unexpected result %v", b.set)
+	}
+}
+
+func TestGrowable(t *testing.T) {
+	b := NewGrowable(0)
+	if err := b.Set(200); err != nil {
+		t.Errorf("Unexpected error on Set past the end: %v", err)
+	}
+	if b.Size() != 201 {
+		t.Errorf("Expected size 201, got %d", b.Size())
+	}
+	if got, err := b.Get(1000); got || err != nil {
+		t.Errorf("Expected Get past the end to be false with no error, got %v, %v", got, err)
+	}
+
+	b.Not()
+	if b.Count() != 200 {
+		t.Errorf("Expected count 200 after Not, got %d", b.Count())
+	}
+
+	o := fromBits(300, 299)
+	b.UnionWith(&o)
+	if b.Size() != 300 {
+		t.Errorf("Expected size 300 after UnionWith, got %d", b.Size())
+	}
+	if b.Count() != 201 {
+		t.Errorf("Expected count 201 after UnionWith, got %d", b.Count())
+	}
+}
+
+func TestClone(t *testing.T) {
+	b := fromBits(10, 1)
+	c := b.Clone()
+	c.Set(2)
+	if got, _ := b.Get(2); got {
+		t.Error("Modifying a clone should not modify the original")
+	}
+}
+
+func TestScanning(t *testing.T) {
+	b := fromBits(300, 3, 64, 65, 200)
+
+	tests := []struct {
+		name     string
+		fn       func(int) (int, bool)
+		from     int
+		expected int
+		found    bool
+	}{
+		{"next set from start", b.NextSet, 0, 3, true},
+		{"next set on set bit", b.NextSet, 64, 64, true},
+		{"next set across words", b.NextSet, 66, 200, true},
+		{"next set none", b.NextSet, 201, 0, false},
+		{"next set negative", b.NextSet, -5, 3, true},
+		{"prev set on set bit", b.PrevSet, 65, 65, true},
+		{"prev set across words", b.PrevSet, 63, 3, true},
+		{"prev set past end", b.PrevSet, 1000, 200, true},
+		{"prev set none", b.PrevSet, 2, 0, false},
+		{"next clear", b.NextClear, 64, 66, true},
+		{"next clear on clear bit", b.NextClear, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := tt.fn(tt.from)
+			if found != tt.found || (found && got != tt.expected) {
+				t.Errorf("got (%d, %v), want (%d, %v)", got, found, tt.expected, tt.found)
+			}
+		})
+	}
+
+	if got, _ := b.FirstSet(); got != 3 {
+		t.Errorf("Expected first set bit 3, got %d", got)
+	}
+	if got, _ := b.LastSet(); got != 200 {
+		t.Errorf("Expected last set bit 200, got %d", got)
+	}
+	if _, found := New(10).FirstSet(); found {
+		t.Error("Expected no set bit in an empty bitset")
+	}
+
+	full := Complement(New(70))
+	if _, found := full.NextClear(0); found {
+		t.Error("Expected no clear bit in a full bitset")
+	}
+}
+
+func TestOnes(t *testing.T) {
+	bits := []int{0, 5, 63, 64, 127, 128, 1000}
+	b := fromBits(1001, bits...)
+
+	got := slices.Collect(b.Ones())
+	if !slices.Equal(got, bits) {
+		t.Errorf("Ones() = %v, want %v", got, bits)
+	}
+
+	var first []int
+	for p := range b.Ones() {
+		if len(first) == 2 {
+			break
+		}
+		first = append(first, p)
+	}
+	if !slices.Equal(first, bits[:2]) {
+		t.Errorf("Expected early break to yield %v, got %v", bits[:2], first)
+	}
+}
+
+func randomBitset(n int, r *rand.Rand) (Bitset, []bool) {
+	b := New(n)
+	naive := make([]bool, n)
+	for i := 0; i < n; i++ {
+		if r.Intn(2) == 0 {
+			b.Set(i)
+			naive[i] = true
+		}
+	}
+	return b, naive
+}
+
+func checkNaive(t *testing.T, b Bitset, naive []bool) {
+	t.Helper()
+	for i, want := range naive {
+		if got, _ := b.Get(i); got != want {
+			t.Fatalf("bit %d = %v, want %v", i, got, want)
+		}
+	}
+	want := 0
+	for _, v := range naive {
+		if v {
+			want++
+		}
+	}
+	if b.Count() != want {
+		t.Fatalf("Count() = %d, want %d", b.Count(), want)
+	}
+}
+
+func TestRangeOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	const n = 200
+	for iter := 0; iter < 500; iter++ {
+		b, naive := randomBitset(n, r)
+		lo := r.Intn(n + 1)
+		hi := lo + r.Intn(n-lo+1)
+
+		count, err := b.CountRange(lo, hi)
+		if err != nil {
+			t.Fatalf("Unexpected error on CountRange(%d, %d): %v", lo, hi, err)
+		}
+		want := 0
+		for i := lo; i < hi; i++ {
+			if naive[i] {
+				want++
+			}
+		}
+		if count != want {
+			t.Fatalf("CountRange(%d, %d) = %d, want %d", lo, hi, count, want)
+		}
+
+		switch iter % 3 {
+		case 0:
+			b.SetRange(lo, hi)
+		case 1:
+			b.ClearRange(lo, hi)
+		case 2:
+			b.FlipRange(lo, hi)
+		}
+		for i := lo; i < hi; i++ {
+			naive[i] = iter%3 == 0 || (iter%3 == 2 && !naive[i])
+		}
+		checkNaive(t, b, naive)
+	}
+}
+
+func TestRangeErrors(t *testing.T) {
+	b := New(10)
+	for _, rg := range [][2]int{{-1, 3}, {3, 11}, {5, 4}} {
+		if err := b.SetRange(rg[0], rg[1]); err == nil {
+			t.Errorf("Expected error on SetRange(%d, %d)", rg[0], rg[1])
+		}
+		if _, err := b.CountRange(rg[0], rg[1]); err == nil {
+			t.Errorf("Expected error on CountRange(%d, %d)", rg[0], rg[1])
+		}
+	}
+
+	g := NewGrowable(10)
+	if err := g.SetRange(60, 140); err != nil {
+		t.Errorf("Unexpected error on growable SetRange: %v", err)
+	}
+	if g.Size() != 140 || g.Count() != 80 {
+		t.Errorf("Expected size 140 and count 80, got %d and %d", g.Size(), g.Count())
+	}
+}
+
+func TestShifts(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	const n = 190
+	for _, k := range []int{0, 1, 5, 63, 64, 65, 130, 189, 190, 500} {
+		b, naive := randomBitset(n, r)
+		left := b.Clone()
+		left.ShiftLeft(k)
+		right := b.Clone()
+		right.ShiftRight(k)
+
+		wantLeft := make([]bool, n)
+		wantRight := make([]bool, n)
+		for i := 0; i < n; i++ {
+			if i+k < n {
+				wantLeft[i+k] = naive[i]
+			}
+			if i-k >= 0 {
+				wantRight[i-k] = naive[i]
+			}
+		}
+		checkNaive(t, left, wantLeft)
+		checkNaive(t, right, wantRight)
+	}
+}
+
+func TestShiftGrowable(t *testing.T) {
+	b := NewGrowable(6)
+	b.Set(5)
+	b.ShiftLeft(1)
+	if b.Size() != 6 || b.Count() != 0 {
+		t.Errorf("Expected ShiftLeft to drop bit 5 and keep size 6, got size %d and %d set", b.Size(), b.Count())
+	}
+
+	// growing first keeps the bit
+	b.Set(5)
+	b.Set(6)
+	b.Clear(6)
+	b.ShiftLeft(1)
+	if ok, _ := b.Get(6); !ok {
+		t.Error("Expected bit 5 to move to 6 once the bitset has room for it")
+	}
+}
+
+func TestShiftExtremes(t *testing.T) {
+	for _, k := range []int{math.MinInt, -190, 190, math.MaxInt} {
+		b := fromBits(190, 0, 64, 189)
+		b.ShiftLeft(k)
+		if b.Count() != 0 {
+			t.Errorf("Expected ShiftLeft(%d) to clear every bit, got %d set", k, b.Count())
+		}
+		b = fromBits(190, 0, 64, 189)
+		b.ShiftRight(k)
+		if b.Count() != 0 {
+			t.Errorf("Expected ShiftRight(%d) to clear every bit, got %d set", k, b.Count())
+		}
+	}
+
+	b := fromBits(190, 5)
+	b.ShiftLeft(-189)
+	if b.Count() != 0 {
+		t.Errorf("Expected bit 5 to be dropped by ShiftLeft(-189), got %d set", b.Count())
+	}
+	b = fromBits(190, 0)
+	b.ShiftRight(-189)
+	if ok, _ := b.Get(189); !ok {
+		t.Error("Expected ShiftRight(-189) to move bit 0 to 189")
+	}
+}
+
+func TestSubsetSum(t *testing.T) {
+	weights := []int{3, 5, 7}
+	dp := New(20)
+	dp.Set(0)
+	for _, w := range weights {
+		s := dp.Clone()
+		s.ShiftLeft(w)
+		dp.UnionWith(&s)
+	}
+	want := []int{0, 3, 5, 7, 8, 10, 12, 15}
+	if got := slices.Collect(dp.Ones()); !slices.Equal(got, want) {
+		t.Errorf("Reachable sums = %v, want %v", got, want)
+	}
+}
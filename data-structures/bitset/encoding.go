@@ -0,0 +1,169 @@
+package bitset
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrTruncated = errors.New("bitset encoding is truncated")
+	ErrOversized = errors.New("bitset encoding is longer than its length prefix")
+)
+
+var (
+	_ encoding.BinaryMarshaler   = Bitset{}
+	_ encoding.BinaryUnmarshaler = &Bitset{}
+	_ encoding.TextMarshaler     = Bitset{}
+	_ encoding.TextUnmarshaler   = &Bitset{}
+	_ fmt.Formatter              = Bitset{}
+)
+
+const _prefixSize = 8
+
+// Binary format: the size of the bitset as a little-endian uint64, followed by the
+// words of the bitset, also little-endian
+func (b Bitset) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, _prefixSize+8*len(b.set))
+	data = binary.LittleEndian.AppendUint64(data, uint64(b.n))
+	for _, w := range b.set {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+func (b *Bitset) UnmarshalBinary(data []byte) error {
+	if len(data) < _prefixSize {
+		return ErrTruncated
+	}
+	n := binary.LittleEndian.Uint64(data)
+	data = data[_prefixSize:]
+	// compare against the input before allocating anything, so a corrupted prefix
+	// can't make us allocate a huge bitset
+	if n > uint64(len(data))*8 {
+		return ErrTruncated
+	}
+	ans := New(int(n))
+	if len(data) > 8*len(ans.set) {
+		return ErrOversized
+	}
+	if len(data) < 8*len(ans.set) {
+		return ErrTruncated
+	}
+	for i := range ans.set {
+		ans.set[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	if r := ans.n % _byteSize; r != 0 && ans.set[len(ans.set)-1]>>r != 0 {
+		return errors.New("bitset encoding has bits set past its length")
+	}
+	b.set, b.n = ans.set, ans.n
+	return nil
+}
+
+// Text formats follow the usual convention for numbers: the leftmost character holds
+// the highest position, so bit 0 is the last character.
+
+// MarshalText writes one '0' or '1' per bit
+func (b Bitset) MarshalText() ([]byte, error) {
+	data := make([]byte, b.n)
+	for p := 0; p < b.n; p++ {
+		data[b.n-1-p] = '0' + byte((b.set[p/_byteSize]>>(p%_byteSize))&1)
+	}
+	return data, nil
+}
+
+// UnmarshalText reads a string of '0's and '1's, one per bit
+func (b *Bitset) UnmarshalText(text []byte) error {
+	ans := New(len(text))
+	for i, c := range text {
+		p := len(text) - 1 - i
+		switch c {
+		case '0':
+		case '1':
+			ans.set[p/_byteSize] |= 1 << (p % _byteSize)
+		default:
+			return fmt.Errorf("invalid character %q at position %v of bitset text", c, i)
+		}
+	}
+	b.set, b.n = ans.set, ans.n
+	return nil
+}
+
+// MarshalHex writes one lowercase hex digit per 4 bits
+func (b Bitset) MarshalHex() ([]byte, error) {
+	const digits = "0123456789abcdef"
+	data := make([]byte, (b.n+3)/4)
+	for i := range data {
+		p := 4 * i
+		nibble := (b.set[p/_byteSize] >> (p % _byteSize)) & 0xF
+		data[len(data)-1-i] = digits[nibble]
+	}
+	return data, nil
+}
+
+// UnmarshalHex reads hex digits, 4 bits per digit. The size of the resulting bitset
+// is 4 times the number of digits.
+func (b *Bitset) UnmarshalHex(text []byte) error {
+	ans := New(4 * len(text))
+	for i, c := range text {
+		var nibble uint64
+		switch {
+		case '0' <= c && c <= '9':
+			nibble = uint64(c - '0')
+		case 'a' <= c && c <= 'f':
+			nibble = uint64(c-'a') + 10
+		case 'A' <= c && c <= 'F':
+			nibble = uint64(c-'A') + 10
+		default:
+			return fmt.Errorf("invalid character %q at position %v of bitset hex", c, i)
+		}
+		p := 4 * (len(text) - 1 - i)
+		ans.set[p/_byteSize] |= nibble << (p % _byteSize)
+	}
+	b.set, b.n = ans.set, ans.n
+	return nil
+}
+
+func (b Bitset) String() string {
+	text, _ := b.MarshalText()
+	return string(text)
+}
+
+// Format implements fmt.Formatter. %v, %s and %b print the bits, %x and %X print them
+// in hex and %d prints the positions of the set bits, like a slice.
+func (b Bitset) Format(f fmt.State, verb rune) {
+	var out string
+	switch verb {
+	case 'v', 's', 'b':
+		out = b.String()
+	case 'x', 'X':
+		hex, _ := b.MarshalHex()
+		out = string(hex)
+		if verb == 'X' {
+			out = strings.ToUpper(out)
+		}
+		if f.Flag('#') {
+			out = "0x" + out
+		}
+	case 'd':
+		ones := make([]int, 0, b.Count())
+		for p := range b.Ones() {
+			ones = append(ones, p)
+		}
+		out = fmt.Sprint(ones)
+	default:
+		fmt.Fprintf(f, "%%!%c(bitset.Bitset=%s)", verb, b.String())
+		return
+	}
+	if w, ok := f.Width(); ok && len(out) < w {
+		pad := strings.Repeat(" ", w-len(out))
+		if f.Flag('-') {
+			out += pad
+		} else {
+			out = pad + out
+		}
+	}
+	f.Write([]byte(out))
+}
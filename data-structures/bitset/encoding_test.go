@@ -0,0 +1,131 @@
+package bitset
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for _, n := range []int{0, 1, 63, 64, 65, 1000} {
+		b, _ := randomBitset(n, r)
+		data, err := b.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error on MarshalBinary: %v", err)
+		}
+		if len(data) != 8+8*words(n) {
+			t.Errorf("Expected %d bytes for size %d, got %d", 8+8*words(n), n, len(data))
+		}
+
+		var got Bitset
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Unexpected error on UnmarshalBinary: %v", err)
+		}
+		if !got.Equal(b) {
+			t.Errorf("Round trip of size %d changed the bitset", n)
+		}
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	b := fromBits(100, 1, 99)
+	data, _ := b.MarshalBinary()
+	hugePrefix := append([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F}, data[8:]...)
+	tailBits := append([]byte{}, data...)
+	tailBits[len(tailBits)-1] = 0x80
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{"empty", nil, ErrTruncated},
+		{"short prefix", data[:5], ErrTruncated},
+		{"missing words", data[:len(data)-8], ErrTruncated},
+		{"missing bytes", data[:len(data)-1], ErrTruncated},
+		{"extra bytes", append(append([]byte{}, data...), 0), ErrOversized},
+		{"huge prefix", hugePrefix, ErrTruncated},
+		{"bits past the end", tailBits, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Bitset
+			err := got.UnmarshalBinary(tt.data)
+			if err == nil {
+				t.Fatal("Expected error, got none")
+			}
+			if tt.expected != nil && !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	b := fromBits(10, 0, 3, 9)
+	text, _ := b.MarshalText()
+	if string(text) != "1000001001" {
+		t.Errorf("MarshalText() = %s, want 1000001001", text)
+	}
+
+	var got Bitset
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatalf("Unexpected error on UnmarshalText: %v", err)
+	}
+	if !got.Equal(b) {
+		t.Errorf("Round trip changed the bitset to %s", got)
+	}
+
+	if err := got.UnmarshalText([]byte("0120")); err == nil {
+		t.Error("Expected error on invalid character")
+	}
+}
+
+func TestHexRoundTrip(t *testing.T) {
+	b := fromBits(12, 0, 5, 11)
+	hex, _ := b.MarshalHex()
+	if string(hex) != "821" {
+		t.Errorf("MarshalHex() = %s, want 821", hex)
+	}
+
+	var got Bitset
+	if err := got.UnmarshalHex([]byte("8A1")); err != nil {
+		t.Fatalf("Unexpected error on UnmarshalHex: %v", err)
+	}
+	if !got.Equal(fromBits(12, 0, 5, 7, 11)) {
+		t.Errorf("UnmarshalHex(8A1) = %s", got)
+	}
+
+	if err := got.UnmarshalHex([]byte("0g")); err == nil {
+		t.Error("Expected error on invalid character")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	b := fromBits(8, 1, 4, 7)
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "10010010"},
+		{"%s", "10010010"},
+		{"%b", "10010010"},
+		{"%x", "92"},
+		{"%#X", "0x92"},
+		{"%d", "[1 4 7]"},
+		{"%10v", "  10010010"},
+		{"%-4x|", "92  |"},
+		{"%q", "%!q(bitset.Bitset=10010010)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, b); got != tt.expected {
+				t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.expected)
+			}
+		})
+	}
+}
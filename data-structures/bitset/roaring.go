@@ -0,0 +1,579 @@
+package bitset
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// Roaring is a compressed bitset. The universe is split in chunks of 2^16 bits and
+// only the chunks with at least one bit set are stored. Each chunk is stored as a
+// sorted array, a bitmap or a list of runs. Set and Clear only switch between arrays
+// and bitmaps as a chunk fills up or empties, so after loading long ranges of bits call
+// Optimize to store them as runs.
+type Roaring struct {
+	keys       []int // sorted, keys[i] is the chunk stored at containers[i]
+	containers []container
+	n          int
+}
+
+var _ Set = &Roaring{}
+
+const (
+	_chunkBits   = 16
+	_chunkSize   = 1 << _chunkBits
+	_bitmapWords = _chunkSize / _byteSize
+	_arrayMax    = 4096 // past this many elements a bitmap takes less memory than an array
+	_runMax      = 2048 // past this many runs a bitmap takes less memory than a run list
+)
+
+func NewRoaring(n int) *Roaring {
+	return &Roaring{n: n}
+}
+
+func (r *Roaring) Size() int {
+	return r.n
+}
+
+func (r *Roaring) Clone() *Roaring {
+	ans := &Roaring{
+		keys:       slices.Clone(r.keys),
+		containers: make([]container, len(r.containers)),
+		n:          r.n,
+	}
+	for i, c := range r.containers {
+		ans.containers[i] = c.clone()
+	}
+	return ans
+}
+
+func split(p int) (int, uint16) {
+	return p >> _chunkBits, uint16(p)
+}
+
+func (r *Roaring) Set(p int) error {
+	if p < 0 || p >= r.n {
+		return outOfBounds(p, r.n)
+	}
+	key, lo := split(p)
+	i, found := slices.BinarySearch(r.keys, key)
+	if !found {
+		r.keys = slices.Insert(r.keys, i, key)
+		r.containers = slices.Insert(r.containers, i, container(arrayContainer{}))
+	}
+	r.containers[i] = r.containers[i].set(lo)
+	return nil
+}
+
+func (r *Roaring) Clear(p int) error {
+	if p < 0 || p >= r.n {
+		return outOfBounds(p, r.n)
+	}
+	key, lo := split(p)
+	i, found := slices.BinarySearch(r.keys, key)
+	if !found {
+		return nil
+	}
+	r.containers[i] = r.containers[i].clear(lo)
+	if r.containers[i].count() == 0 {
+		r.keys = slices.Delete(r.keys, i, i+1)
+		r.containers = slices.Delete(r.containers, i, i+1)
+	}
+	return nil
+}
+
+func (r *Roaring) Get(p int) (bool, error) {
+	if p < 0 || p >= r.n {
+		return false, outOfBounds(p, r.n)
+	}
+	key, lo := split(p)
+	i, found := slices.BinarySearch(r.keys, key)
+	if !found {
+		return false, nil
+	}
+	return r.containers[i].get(lo), nil
+}
+
+func (r *Roaring) Count() int {
+	ans := 0
+	for _, c := range r.containers {
+		ans += c.count()
+	}
+	return ans
+}
+
+// Optimize converts every chunk to the representation that takes the least memory.
+// It is the only way a chunk becomes a run list, so callers must run it themselves.
+func (r *Roaring) Optimize() {
+	for i, c := range r.containers {
+		r.containers[i] = optimize(c)
+	}
+}
+
+func (r *Roaring) Ones() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, c := range r.containers {
+			base := r.keys[i] << _chunkBits
+			for lo := range c.values() {
+				if !yield(base + int(lo)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// UnionWith sets every bit of r that is set in o. Bits of o past the size of r are
+// ignored.
+func (r *Roaring) UnionWith(o Set) {
+	x, ok := o.(*Roaring)
+	if !ok {
+		for p := range o.Ones() {
+			if p >= r.n {
+				break
+			}
+			r.Set(p)
+		}
+		return
+	}
+	ans := RoaringUnion(r, x)
+	r.keys, r.containers = ans.keys, ans.containers
+	r.removeFrom(r.n)
+}
+
+func (r *Roaring) IntersectionWith(o Set) {
+	x, ok := o.(*Roaring)
+	if !ok {
+		// clearing while iterating would shift the containers, so build it anew
+		ans := NewRoaring(r.n)
+		for p := range r.Ones() {
+			if in, _ := o.Get(p); in {
+				ans.Set(p)
+			}
+		}
+		r.keys, r.containers = ans.keys, ans.containers
+		return
+	}
+	ans := RoaringIntersection(r, x)
+	r.keys, r.containers = ans.keys, ans.containers
+}
+
+// removeFrom clears every bit at a position >= p
+func (r *Roaring) removeFrom(p int) {
+	for len(r.keys) > 0 {
+		last := len(r.keys) - 1
+		base := r.keys[last] << _chunkBits
+		if base+_chunkSize <= p {
+			return
+		}
+		w := Bitset{set: r.containers[last].words(), n: _chunkSize}
+		w.ClearRange(max(p-base, 0), _chunkSize)
+		if c := fromWords(w.set); c != nil {
+			r.containers[last] = c
+			return
+		}
+		r.keys = r.keys[:last]
+		r.containers = r.containers[:last]
+	}
+}
+
+// Functions. Like Union and Intersection, the result has the size of the largest operand.
+
+func RoaringUnion(x, y *Roaring) *Roaring {
+	ans := NewRoaring(max(x.n, y.n))
+	i, j := 0, 0
+	for i < len(x.keys) || j < len(y.keys) {
+		switch {
+		case j == len(y.keys) || (i < len(x.keys) && x.keys[i] < y.keys[j]):
+			ans.keys = append(ans.keys, x.keys[i])
+			ans.containers = append(ans.containers, x.containers[i].clone())
+			i++
+		case i == len(x.keys) || y.keys[j] < x.keys[i]:
+			ans.keys = append(ans.keys, y.keys[j])
+			ans.containers = append(ans.containers, y.containers[j].clone())
+			j++
+		default:
+			ans.keys = append(ans.keys, x.keys[i])
+			ans.containers = append(ans.containers, union(x.containers[i], y.containers[j]))
+			i++
+			j++
+		}
+	}
+	return ans
+}
+
+func RoaringIntersection(x, y *Roaring) *Roaring {
+	ans := NewRoaring(max(x.n, y.n))
+	i, j := 0, 0
+	for i < len(x.keys) && j < len(y.keys) {
+		switch {
+		case x.keys[i] < y.keys[j]:
+			i++
+		case y.keys[j] < x.keys[i]:
+			j++
+		default:
+			if c := intersection(x.containers[i], y.containers[j]); c != nil {
+				ans.keys = append(ans.keys, x.keys[i])
+				ans.containers = append(ans.containers, c)
+			}
+			i++
+			j++
+		}
+	}
+	return ans
+}
+
+// Containers
+
+// container holds the low 16 bits of the elements of a chunk. Mutations return the
+// container to use from then on, which may have switched representation.
+type container interface {
+	get(x uint16) bool
+	set(x uint16) container
+	clear(x uint16) container
+	count() int
+	values() iter.Seq[uint16]
+	words() []uint64 // the chunk as a freshly allocated bitmap
+	clone() container
+}
+
+// fromWords builds the smallest array or bitmap container holding the given bitmap.
+// It returns nil for an empty bitmap.
+func fromWords(w []uint64) container {
+	card := 0
+	for _, x := range w {
+		card += bits.OnesCount64(x)
+	}
+	if card == 0 {
+		return nil
+	}
+	if card > _arrayMax {
+		return &bitmapContainer{bits: w, card: card}
+	}
+	a := make(arrayContainer, 0, card)
+	for lo := range (&bitmapContainer{bits: w}).values() {
+		a = append(a, lo)
+	}
+	return a
+}
+
+// optimize returns the representation of c that takes the least memory
+func optimize(c container) container {
+	w := c.words()
+	card, runs := 0, 0
+	for i, x := range w {
+		card += bits.OnesCount64(x)
+		// a run starts at every set bit whose predecessor is not set
+		prev := x << 1
+		if i > 0 {
+			prev |= w[i-1] >> (_byteSize - 1)
+		}
+		runs += bits.OnesCount64(x &^ prev)
+	}
+	arraySize, bitmapSize, runSize := 2*card, 8*_bitmapWords, 4*runs
+	switch {
+	case runSize < min(arraySize, bitmapSize):
+		return runsFromWords(w)
+	case arraySize <= bitmapSize:
+		return fromWords(w)
+	default:
+		return &bitmapContainer{bits: w, card: card}
+	}
+}
+
+func union(a, b container) container {
+	x, okx := a.(arrayContainer)
+	y, oky := b.(arrayContainer)
+	if okx && oky && len(x)+len(y) <= _arrayMax {
+		ans := make(arrayContainer, 0, len(x)+len(y))
+		i, j := 0, 0
+		for i < len(x) || j < len(y) {
+			switch {
+			case j == len(y) || (i < len(x) && x[i] < y[j]):
+				ans = append(ans, x[i])
+				i++
+			case i == len(x) || y[j] < x[i]:
+				ans = append(ans, y[j])
+				j++
+			default:
+				ans = append(ans, x[i])
+				i++
+				j++
+			}
+		}
+		return ans
+	}
+	w := a.words()
+	for i, v := range b.words() {
+		w[i] |= v
+	}
+	return fromWords(w)
+}
+
+func intersection(a, b container) container {
+	x, okx := a.(arrayContainer)
+	y, oky := b.(arrayContainer)
+	if okx && oky {
+		var ans arrayContainer
+		i, j := 0, 0
+		for i < len(x) && j < len(y) {
+			switch {
+			case x[i] < y[j]:
+				i++
+			case y[j] < x[i]:
+				j++
+			default:
+				ans = append(ans, x[i])
+				i++
+				j++
+			}
+		}
+		if len(ans) == 0 {
+			return nil
+		}
+		return ans
+	}
+	if okx || oky { // probe the other container with every element of the array
+		if !okx {
+			x, b = y, a
+		}
+		var ans arrayContainer
+		for _, lo := range x {
+			if b.get(lo) {
+				ans = append(ans, lo)
+			}
+		}
+		if len(ans) == 0 {
+			return nil
+		}
+		return ans
+	}
+	w := a.words()
+	for i, v := range b.words() {
+		w[i] &= v
+	}
+	return fromWords(w)
+}
+
+// arrayContainer is a sorted list of the elements of the chunk, used for sparse chunks
+
+type arrayContainer []uint16
+
+func (a arrayContainer) get(x uint16) bool {
+	_, found := slices.BinarySearch(a, x)
+	return found
+}
+
+func (a arrayContainer) set(x uint16) container {
+	i, found := slices.BinarySearch(a, x)
+	if found {
+		return a
+	}
+	if len(a) >= _arrayMax {
+		return (&bitmapContainer{bits: a.words(), card: len(a)}).set(x)
+	}
+	return slices.Insert(a, i, x)
+}
+
+func (a arrayContainer) clear(x uint16) container {
+	i, found := slices.BinarySearch(a, x)
+	if !found {
+		return a
+	}
+	return slices.Delete(a, i, i+1)
+}
+
+func (a arrayContainer) count() int {
+	return len(a)
+}
+
+func (a arrayContainer) values() iter.Seq[uint16] {
+	return slices.Values(a)
+}
+
+func (a arrayContainer) words() []uint64 {
+	w := make([]uint64, _bitmapWords)
+	for _, x := range a {
+		w[x/_byteSize] |= 1 << (x % _byteSize)
+	}
+	return w
+}
+
+func (a arrayContainer) clone() container {
+	return slices.Clone(a)
+}
+
+// bitmapContainer stores one bit per element of the chunk, used for dense chunks
+
+type bitmapContainer struct {
+	bits []uint64
+	card int
+}
+
+func (b *bitmapContainer) get(x uint16) bool {
+	return (b.bits[x/_byteSize]>>(x%_byteSize))&1 == 1
+}
+
+func (b *bitmapContainer) set(x uint16) container {
+	if !b.get(x) {
+		b.bits[x/_byteSize] |= 1 << (x % _byteSize)
+		b.card++
+	}
+	return b
+}
+
+func (b *bitmapContainer) clear(x uint16) container {
+	if b.get(x) {
+		b.bits[x/_byteSize] &^= 1 << (x % _byteSize)
+		b.card--
+	}
+	if b.card <= _arrayMax/2 { // leave some slack so we don't flip back and forth
+		if c := fromWords(b.bits); c != nil {
+			return c
+		}
+		return arrayContainer{}
+	}
+	return b
+}
+
+func (b *bitmapContainer) count() int {
+	return b.card
+}
+
+func (b *bitmapContainer) values() iter.Seq[uint16] {
+	return func(yield func(uint16) bool) {
+		for i, word := range b.bits {
+			for word != 0 {
+				if !yield(uint16(i*_byteSize + bits.TrailingZeros64(word))) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+func (b *bitmapContainer) words() []uint64 {
+	return slices.Clone(b.bits)
+}
+
+func (b *bitmapContainer) clone() container {
+	return &bitmapContainer{bits: slices.Clone(b.bits), card: b.card}
+}
+
+// runContainer stores the chunk as sorted, non-adjacent intervals, used for chunks
+// made of long stretches of set bits
+
+type run struct {
+	start, last uint16 // inclusive
+}
+
+type runContainer []run
+
+func runsFromWords(w []uint64) runContainer {
+	var ans runContainer
+	for lo := range (&bitmapContainer{bits: w}).values() {
+		if k := len(ans) - 1; k >= 0 && ans[k].last+1 == lo {
+			ans[k].last = lo
+		} else {
+			ans = append(ans, run{lo, lo})
+		}
+	}
+	return ans
+}
+
+// find returns the index of the first run that starts after x
+func (rc runContainer) find(x uint16) int {
+	i, _ := slices.BinarySearchFunc(rc, x, func(r run, x uint16) int {
+		if r.start <= x {
+			return -1
+		}
+		return 1
+	})
+	return i
+}
+
+func (rc runContainer) get(x uint16) bool {
+	i := rc.find(x)
+	return i > 0 && x <= rc[i-1].last
+}
+
+func (rc runContainer) set(x uint16) container {
+	if rc.get(x) {
+		return rc
+	}
+	i := rc.find(x)
+	joinsPrev := i > 0 && rc[i-1].last+1 == x
+	joinsNext := i < len(rc) && x+1 == rc[i].start
+	switch {
+	case joinsPrev && joinsNext:
+		rc[i-1].last = rc[i].last
+		return slices.Delete(rc, i, i+1)
+	case joinsPrev:
+		rc[i-1].last = x
+	case joinsNext:
+		rc[i].start = x
+	default:
+		if len(rc) >= _runMax {
+			return fromWords(rc.words()).set(x)
+		}
+		return slices.Insert(rc, i, run{x, x})
+	}
+	return rc
+}
+
+func (rc runContainer) clear(x uint16) container {
+	if !rc.get(x) {
+		return rc
+	}
+	i := rc.find(x) - 1
+	r := rc[i]
+	switch {
+	case r.start == r.last:
+		return slices.Delete(rc, i, i+1)
+	case x == r.start:
+		rc[i].start++
+	case x == r.last:
+		rc[i].last--
+	default:
+		if len(rc) >= _runMax {
+			return fromWords(rc.words()).clear(x)
+		}
+		rc[i].last = x - 1
+		return slices.Insert(rc, i+1, run{x + 1, r.last})
+	}
+	return rc
+}
+
+func (rc runContainer) count() int {
+	ans := 0
+	for _, r := range rc {
+		ans += int(r.last-r.start) + 1
+	}
+	return ans
+}
+
+func (rc runContainer) values() iter.Seq[uint16] {
+	return func(yield func(uint16) bool) {
+		for _, r := range rc {
+			for x := int(r.start); x <= int(r.last); x++ {
+				if !yield(uint16(x)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (rc runContainer) words() []uint64 {
+	w := make([]uint64, _bitmapWords)
+	b := Bitset{set: w, n: _chunkSize}
+	for _, r := range rc {
+		b.SetRange(int(r.start), int(r.last)+1)
+	}
+	return w
+}
+
+func (rc runContainer) clone() container {
+	return slices.Clone(rc)
+}
@@ -0,0 +1,185 @@
+package bitset
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// fill sets the same bits on every given Set
+func fill(r *rand.Rand, n int, sets ...Set) {
+	// a sparse chunk, a dense chunk and a chunk made of long runs
+	for i := 0; i < 100; i++ {
+		p := r.Intn(_chunkSize)
+		for _, s := range sets {
+			s.Set(p)
+		}
+	}
+	for i := 0; i < 3*_arrayMax; i++ {
+		p := _chunkSize + r.Intn(_chunkSize)
+		for _, s := range sets {
+			s.Set(p)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		start := 2*_chunkSize + r.Intn(_chunkSize-500)
+		end := start + r.Intn(500)
+		for p := start; p < end; p++ {
+			for _, s := range sets {
+				s.Set(p)
+			}
+		}
+	}
+	for i := 0; i < 50; i++ {
+		p := r.Intn(n)
+		for _, s := range sets {
+			s.Set(p)
+		}
+	}
+}
+
+func checkSameBits(t *testing.T, got, want Set) {
+	t.Helper()
+	if got.Size() != want.Size() {
+		t.Fatalf("Size() = %d, want %d", got.Size(), want.Size())
+	}
+	if got.Count() != want.Count() {
+		t.Fatalf("Count() = %d, want %d", got.Count(), want.Count())
+	}
+	g, w := slices.Collect(got.Ones()), slices.Collect(want.Ones())
+	if !slices.Equal(g, w) {
+		t.Fatalf("Ones() differ: got %d bits, want %d bits", len(g), len(w))
+	}
+}
+
+func TestRoaringMatchesBitset(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 5 * _chunkSize
+	b := New(n)
+	rb := NewRoaring(n)
+	fill(r, n, &b, rb)
+	checkSameBits(t, rb, &b)
+
+	for i := 0; i < 1000; i++ {
+		p := r.Intn(n)
+		got, _ := rb.Get(p)
+		want, _ := b.Get(p)
+		if got != want {
+			t.Fatalf("Get(%d) = %v, want %v", p, got, want)
+		}
+	}
+
+	rb.Optimize()
+	checkSameBits(t, rb, &b)
+
+	// clear most of the bits, so dense chunks go back to being sparse
+	for i := 0; i < 4*_chunkSize; i++ {
+		p := r.Intn(3 * _chunkSize)
+		b.Clear(p)
+		rb.Clear(p)
+	}
+	checkSameBits(t, rb, &b)
+
+	// and set some again, now on top of run containers
+	for i := 0; i < 5000; i++ {
+		p := 2*_chunkSize + r.Intn(_chunkSize)
+		b.Set(p)
+		rb.Set(p)
+	}
+	checkSameBits(t, rb, &b)
+}
+
+func TestRoaringOutOfBounds(t *testing.T) {
+	rb := NewRoaring(100)
+	for _, p := range []int{-1, 100} {
+		if err := rb.Set(p); err == nil {
+			t.Errorf("Expected error on Set(%d)", p)
+		}
+		if _, err := rb.Get(p); err == nil {
+			t.Errorf("Expected error on Get(%d)", p)
+		}
+		if err := rb.Clear(p); err == nil {
+			t.Errorf("Expected error on Clear(%d)", p)
+		}
+	}
+}
+
+func TestRoaringSetOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	const n = 4 * _chunkSize
+	x, y := New(n), New(n+10)
+	rx, ry := NewRoaring(n), NewRoaring(n+10)
+	fill(r, n, &x, rx)
+	fill(r, n, &y, ry)
+	ry.Set(n + 5)
+	y.Set(n + 5)
+	rx.Optimize()
+
+	union := Union(x, y)
+	intersection := Intersection(x, y)
+	rintersection := RoaringIntersection(rx, ry)
+	checkSameBits(t, RoaringUnion(rx, ry), &union)
+	checkSameBits(t, rintersection, &intersection)
+
+	x.UnionWith(&y)
+	rx.UnionWith(ry)
+	checkSameBits(t, rx, &x)
+
+	y.IntersectionWith(&intersection)
+	ry.IntersectionWith(rintersection)
+	checkSameBits(t, ry, &y)
+}
+
+func TestRoaringContainerKinds(t *testing.T) {
+	rb := NewRoaring(3 * _chunkSize)
+	rb.Set(5)
+	for p := _chunkSize; p < 2*_chunkSize; p += 2 {
+		rb.Set(p)
+	}
+	for p := 2 * _chunkSize; p < 3*_chunkSize; p++ {
+		rb.Set(p)
+	}
+	rb.Optimize()
+
+	if _, ok := rb.containers[0].(arrayContainer); !ok {
+		t.Errorf("Expected sparse chunk to be an array, got %T", rb.containers[0])
+	}
+	if _, ok := rb.containers[2].(runContainer); !ok {
+		t.Errorf("Expected full chunk to be a run list, got %T", rb.containers[2])
+	}
+	if _, ok := rb.containers[1].(*bitmapContainer); !ok {
+		t.Errorf("Expected alternating chunk to be a bitmap, got %T", rb.containers[1])
+	}
+}
+
+// Every kind of Set can be combined with every other through the interface
+func TestMixedSetOperations(t *testing.T) {
+	const n = 4 * _chunkSize
+	kinds := map[string]func() Set{
+		"Bitset":       func() Set { b := New(n); return &b },
+		"Roaring":      func() Set { return NewRoaring(n) },
+		"AtomicBitset": func() Set { return NewAtomic(n) },
+	}
+	x, y := New(n), New(n)
+	fill(rand.New(rand.NewSource(3)), n, &x)
+	fill(rand.New(rand.NewSource(4)), n, &y)
+	union, intersection := Union(x, y), Intersection(x, y)
+
+	for xname, newX := range kinds {
+		for yname, newY := range kinds {
+			t.Run(xname+"/"+yname, func(t *testing.T) {
+				a, b := newX(), newY()
+				fill(rand.New(rand.NewSource(3)), n, a)
+				fill(rand.New(rand.NewSource(4)), n, b)
+				a.UnionWith(b)
+				checkSameBits(t, a, &union)
+
+				a, b = newX(), newY()
+				fill(rand.New(rand.NewSource(3)), n, a)
+				fill(rand.New(rand.NewSource(4)), n, b)
+				a.IntersectionWith(b)
+				checkSameBits(t, a, &intersection)
+			})
+		}
+	}
+}
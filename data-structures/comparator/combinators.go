@@ -0,0 +1,105 @@
+package comparator
+
+// Combinators build new comparators out of existing ones. Each returns a concrete
+// type, so the result can also be used as a type parameter, as in heap.Heap[T, C].
+
+type ReverseComparator[T any, C Comparator[T]] struct {
+	c C
+}
+
+func (rc ReverseComparator[T, C]) Less(a, b T) bool {
+	return rc.c.Less(b, a)
+}
+
+// Reverse inverts the order of c
+func Reverse[T any, C Comparator[T]](c C) ReverseComparator[T, C] {
+	return ReverseComparator[T, C]{c}
+}
+
+type ChainComparator[T any] struct {
+	cs []Comparator[T]
+}
+
+func (cc ChainComparator[T]) Less(a, b T) bool {
+	for _, c := range cc.cs {
+		if c.Less(a, b) {
+			return true
+		}
+		if c.Less(b, a) {
+			return false
+		}
+	}
+	return false
+}
+
+// Then compares lexicographically: the first comparator decides, and each of the
+// following is only used to break the ties of the previous ones
+func Then[T any](cs ...Comparator[T]) ChainComparator[T] {
+	return ChainComparator[T]{cs}
+}
+
+type KeyComparator[T any, K any, C Comparator[K]] struct {
+	key func(T) K
+	c   C
+}
+
+func (kc KeyComparator[T, K, C]) Less(a, b T) bool {
+	return kc.c.Less(kc.key(a), kc.key(b))
+}
+
+// By compares the keys extracted from each element, using c
+func By[T any, K any, C Comparator[K]](key func(T) K, c C) KeyComparator[T, K, C] {
+	return KeyComparator[T, K, C]{key, c}
+}
+
+type NilsFirstComparator[T any, C Comparator[T]] struct {
+	c C
+}
+
+func (nc NilsFirstComparator[T, C]) Less(a, b *T) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+	return nc.c.Less(*a, *b)
+}
+
+// NilsFirst compares pointers by the values they point to, with nil before everything else
+func NilsFirst[T any, C Comparator[T]](c C) NilsFirstComparator[T, C] {
+	return NilsFirstComparator[T, C]{c}
+}
+
+type NilsLastComparator[T any, C Comparator[T]] struct {
+	c C
+}
+
+func (nc NilsLastComparator[T, C]) Less(a, b *T) bool {
+	if a == nil || b == nil {
+		return a != nil && b == nil
+	}
+	return nc.c.Less(*a, *b)
+}
+
+// NilsLast compares pointers by the values they point to, with nil after everything else
+func NilsLast[T any, C Comparator[T]](c C) NilsLastComparator[T, C] {
+	return NilsLastComparator[T, C]{c}
+}
+
+// Adapters to the func(a, b T) int convention of slices.SortFunc and cmp.Compare
+
+// CompareFunc returns a function that is negative when a < b, positive when a > b
+// and zero otherwise
+func CompareFunc[T any](c Comparator[T]) func(a, b T) int {
+	if w, ok := c.(ThreeWay[T]); ok {
+		return w.Compare
+	}
+	return func(a, b T) int {
+		return compareByLess(c, a, b)
+	}
+}
+
+// FromCompareFunc turns a cmp.Compare style function into a comparator
+func FromCompareFunc[T any](compare func(a, b T) int) CustomComparator[T] {
+	return Custom(func(a, b T) bool {
+		return compare(a, b) < 0
+	})
+}
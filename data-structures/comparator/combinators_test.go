@@ -0,0 +1,98 @@
+package comparator
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestReverse(t *testing.T) {
+	rev := Reverse[int](Less[int]{})
+	if !rev.Less(2, 1) || rev.Less(1, 2) || rev.Less(1, 1) {
+		t.Error("Reverse(Less) should behave like Greater")
+	}
+
+	// the zero value of a reversed predefined comparator is ready to use
+	var zero ReverseComparator[int, Greater[int]]
+	if !zero.Less(1, 2) {
+		t.Error("Zero value of ReverseComparator[int, Greater[int]] should behave like Less")
+	}
+}
+
+func TestThenAndBy(t *testing.T) {
+	people := []person{
+		{"carol", 30},
+		{"alice", 25},
+		{"bob", 30},
+		{"dave", 25},
+	}
+
+	byAgeDescThenName := Then(
+		Comparator[person](By(func(p person) int { return p.age }, Greater[int]{})),
+		Comparator[person](By(func(p person) string { return p.name }, Less[string]{})),
+	)
+	slices.SortFunc(people, CompareFunc[person](byAgeDescThenName))
+
+	expected := []person{
+		{"bob", 30},
+		{"carol", 30},
+		{"alice", 25},
+		{"dave", 25},
+	}
+	if !slices.Equal(people, expected) {
+		t.Errorf("Sorted = %v, want %v", people, expected)
+	}
+
+	if Then[int]().Less(1, 2) {
+		t.Error("An empty chain should consider everything equal")
+	}
+}
+
+func TestNils(t *testing.T) {
+	one, two := 1, 2
+	tests := []struct {
+		name      string
+		a, b      *int
+		nilsFirst bool
+		nilsLast  bool
+	}{
+		{"both nil", nil, nil, false, false},
+		{"nil and value", nil, &one, true, false},
+		{"value and nil", &one, nil, false, true},
+		{"values", &one, &two, true, true},
+		{"values reversed", &two, &one, false, false},
+	}
+
+	first := NilsFirst[int](Less[int]{})
+	last := NilsLast[int](Less[int]{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := first.Less(tt.a, tt.b); got != tt.nilsFirst {
+				t.Errorf("NilsFirst.Less = %v, want %v", got, tt.nilsFirst)
+			}
+			if got := last.Less(tt.a, tt.b); got != tt.nilsLast {
+				t.Errorf("NilsLast.Less = %v, want %v", got, tt.nilsLast)
+			}
+		})
+	}
+}
+
+func TestCompareFuncAdapters(t *testing.T) {
+	compare := CompareFunc[int](Less[int]{})
+	for _, pair := range [][2]int{{1, 2}, {2, 2}, {3, 2}} {
+		if got, want := compare(pair[0], pair[1]), cmp.Compare(pair[0], pair[1]); got != want {
+			t.Errorf("compare(%d, %d) = %d, want %d", pair[0], pair[1], got, want)
+		}
+	}
+
+	strs := FromCompareFunc(strings.Compare)
+	if !strs.Less("a", "b") || strs.Less("b", "a") || strs.Less("a", "a") {
+		t.Error("FromCompareFunc(strings.Compare) should behave like Less")
+	}
+}
@@ -9,19 +9,60 @@ type Comparator[T any] interface {
 	Less(a, b T) bool // returns true if a < b, false otherwise
 }
 
-// Predefined comparators
+// ThreeWay is a comparator that tells all three outcomes apart in a single call
+type ThreeWay[T any] interface {
+	Compare(a, b T) int // returns -1 if a < b, 0 if a == b, 1 if a > b
+}
+
+// Equal reports whether neither element is less than the other
+func Equal[T any](c Comparator[T], a, b T) bool {
+	if w, ok := c.(ThreeWay[T]); ok {
+		return w.Compare(a, b) == 0
+	}
+	return !c.Less(a, b) && !c.Less(b, a)
+}
+
+// Compare returns -1, 0 or 1 as a is less than, equal to or greater than b, in a single
+// call when c is a three-way comparator
+func Compare[T any](c Comparator[T], a, b T) int {
+	if w, ok := c.(ThreeWay[T]); ok {
+		return w.Compare(a, b)
+	}
+	return compareByLess(c, a, b)
+}
+
+// compareByLess is the three-way comparison made of two calls to Less
+func compareByLess[T any, C Comparator[T]](c C, a, b T) int {
+	if c.Less(a, b) {
+		return -1
+	}
+	if c.Less(b, a) {
+		return 1
+	}
+	return 0
+}
+
+// Predefined comparators. All of them are also three-way comparators.
 type Less[T cmp.Ordered] struct{}
 
 func (l Less[T]) Less(a, b T) bool {
 	return a < b
 }
 
+func (l Less[T]) Compare(a, b T) int {
+	return cmp.Compare(a, b)
+}
+
 type Greater[T cmp.Ordered] struct{}
 
 func (l Greater[T]) Less(a, b T) bool {
 	return a > b
 }
 
+func (l Greater[T]) Compare(a, b T) int {
+	return cmp.Compare(b, a)
+}
+
 type CustomComparator[T any] struct {
 	customLess func(a, b T) bool
 }
@@ -36,3 +77,39 @@ func (cc CustomComparator[T]) Less(a, b T) bool {
 func Custom[T any](customLess func(a, b T) bool) CustomComparator[T] {
 	return CustomComparator[T]{customLess}
 }
+
+// Adapters between Comparator and ThreeWay. Both adapters implement both interfaces.
+
+type ThreeWayOf[T any, C Comparator[T]] struct {
+	c C
+}
+
+func (w ThreeWayOf[T, C]) Less(a, b T) bool {
+	return w.c.Less(a, b)
+}
+
+func (w ThreeWayOf[T, C]) Compare(a, b T) int {
+	return compareByLess(w.c, a, b)
+}
+
+// AsThreeWay derives a three-way comparison from c, calling Less at most twice
+func AsThreeWay[T any, C Comparator[T]](c C) ThreeWayOf[T, C] {
+	return ThreeWayOf[T, C]{c}
+}
+
+type ComparatorOf[T any, W ThreeWay[T]] struct {
+	w W
+}
+
+func (c ComparatorOf[T, W]) Less(a, b T) bool {
+	return c.w.Compare(a, b) < 0
+}
+
+func (c ComparatorOf[T, W]) Compare(a, b T) int {
+	return c.w.Compare(a, b)
+}
+
+// AsComparator turns a three-way comparator into a Comparator
+func AsComparator[T any, W ThreeWay[T]](w W) ComparatorOf[T, W] {
+	return ComparatorOf[T, W]{w}
+}
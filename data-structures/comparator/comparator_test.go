@@ -1,6 +1,7 @@
 package comparator
 
 import (
+	"cmp"
 	"testing"
 )
 
@@ -81,3 +82,25 @@ func TestCustomComparator(t *testing.T) {
 		comp.Less(1, 2)
 	})
 }
+
+// onlyThreeWay fails the test if Less is called, to check for the single call fast path
+type onlyThreeWay struct{ t *testing.T }
+
+func (o onlyThreeWay) Less(a, b int) bool {
+	o.t.Error("Expected Compare to be used instead of Less")
+	return a < b
+}
+
+func (o onlyThreeWay) Compare(a, b int) int {
+	return cmp.Compare(a, b)
+}
+
+func TestCompare(t *testing.T) {
+	for _, c := range []Comparator[int]{Custom(func(a, b int) bool { return a < b }), onlyThreeWay{t}} {
+		for _, pair := range [][2]int{{1, 2}, {2, 2}, {3, 2}} {
+			if got, want := Compare(c, pair[0], pair[1]), cmp.Compare(pair[0], pair[1]); got != want {
+				t.Errorf("Compare(%T, %d, %d) = %d, want %d", c, pair[0], pair[1], got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package comparator
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Predefined string comparators. None of them depend on the locale. When two
+// different strings are equivalent for a comparator, they are ordered byte-wise, so
+// only identical strings compare as equal.
+
+// CaseInsensitive compares strings rune by rune after lower casing them
+type CaseInsensitive struct{}
+
+func (ci CaseInsensitive) Less(a, b string) bool {
+	return ci.Compare(a, b) < 0
+}
+
+func (ci CaseInsensitive) Compare(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ra, sa := utf8.DecodeRuneInString(a[i:])
+		rb, sb := utf8.DecodeRuneInString(b[j:])
+		if c := compareRunes(unicode.ToLower(ra), unicode.ToLower(rb)); c != 0 {
+			return c
+		}
+		i += sa
+		j += sb
+	}
+	if c := compareInts(len(a)-i, len(b)-j); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// CodePoint compares strings by the Unicode code points of their runes. For valid
+// UTF-8 this is the same as comparing the bytes, but invalid bytes are ordered as
+// utf8.RuneError instead of by their value.
+type CodePoint struct{}
+
+func (cp CodePoint) Less(a, b string) bool {
+	return cp.Compare(a, b) < 0
+}
+
+func (cp CodePoint) Compare(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ra, sa := utf8.DecodeRuneInString(a[i:])
+		rb, sb := utf8.DecodeRuneInString(b[j:])
+		if c := compareRunes(ra, rb); c != 0 {
+			return c
+		}
+		i += sa
+		j += sb
+	}
+	if c := compareInts(len(a)-i, len(b)-j); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// Natural compares runs of decimal digits by their numeric value and everything else
+// byte-wise, so "file2" < "file10". Numbers that are equal apart from leading zeros
+// are ordered by their number of zeros, fewest first.
+type Natural struct{}
+
+func (n Natural) Less(a, b string) bool {
+	return n.Compare(a, b) < 0
+}
+
+func (n Natural) Compare(a, b string) int {
+	zeros := 0 // decides ties between numbers that differ only in leading zeros
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if !isDigit(a[i]) || !isDigit(b[j]) {
+			if c := compareInts(int(a[i]), int(b[j])); c != 0 {
+				return c
+			}
+			i++
+			j++
+			continue
+		}
+
+		// skip leading zeros, then the longer number is the greater one
+		za, zb := i, j
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		if zeros == 0 {
+			zeros = compareInts(i-za, j-zb)
+		}
+		ea, eb := i, j
+		for ea < len(a) && isDigit(a[ea]) {
+			ea++
+		}
+		for eb < len(b) && isDigit(b[eb]) {
+			eb++
+		}
+		if c := compareInts(ea-i, eb-j); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a[i:ea], b[j:eb]); c != 0 {
+			return c
+		}
+		i, j = ea, eb
+	}
+	if c := compareInts(len(a)-i, len(b)-j); c != 0 {
+		return c
+	}
+	if zeros != 0 {
+		return zeros
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func compareRunes(a, b rune) int {
+	return compareInts(int(a), int(b))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package comparator
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStringComparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        ThreeWay[string]
+		a, b     string
+		expected int
+	}{
+		{"case insensitive equal", CaseInsensitive{}, "hello", "hello", 0},
+		{"case insensitive ignores case", CaseInsensitive{}, "Apple", "banana", -1},
+		{"case insensitive unicode", CaseInsensitive{}, "ÉCOLE", "école", -1},
+		{"case insensitive prefix", CaseInsensitive{}, "ab", "ABC", -1},
+		{"code point", CodePoint{}, "a", "é", -1},
+		{"code point astral", CodePoint{}, "\U0001F600", "�", 1},
+		{"code point prefix", CodePoint{}, "abc", "ab", 1},
+		{"natural numbers", Natural{}, "file2", "file10", -1},
+		{"natural text", Natural{}, "file10", "filf1", -1},
+		{"natural leading zeros", Natural{}, "v01", "v1", 1},
+		{"natural leading zeros decide last", Natural{}, "v01b", "v1a", 1},
+		{"natural big numbers", Natural{}, "x123456789012345678901", "x99", 1},
+		{"natural equal", Natural{}, "a1b2", "a1b2", 0},
+		{"natural prefix", Natural{}, "a1", "a1b", -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Compare(tt.a, tt.b); got != tt.expected {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+			}
+			if got := tt.c.Compare(tt.b, tt.a); got != -tt.expected {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.expected)
+			}
+		})
+	}
+}
+
+func TestNaturalSort(t *testing.T) {
+	files := []string{"file10.txt", "file2.txt", "file1.txt", "file02.txt", "File3.txt"}
+	slices.SortFunc(files, CompareFunc[string](Natural{}))
+	expected := []string{"File3.txt", "file1.txt", "file2.txt", "file02.txt", "file10.txt"}
+	if !slices.Equal(files, expected) {
+		t.Errorf("Sorted = %v, want %v", files, expected)
+	}
+}
+
+func TestThreeWayAdapters(t *testing.T) {
+	w := AsThreeWay[int](Custom(func(a, b int) bool { return a < b }))
+	for _, tt := range []struct{ a, b, expected int }{{1, 2, -1}, {2, 2, 0}, {3, 2, 1}} {
+		if got := w.Compare(tt.a, tt.b); got != tt.expected {
+			t.Errorf("Compare(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+
+	c := AsComparator[string](CaseInsensitive{})
+	if !c.Less("a", "B") || c.Less("B", "a") {
+		t.Error("AsComparator(CaseInsensitive) should order a before B")
+	}
+
+	if !Equal[int](Less[int]{}, 3, 3) || Equal[int](Greater[int]{}, 3, 4) {
+		t.Error("Equal should only hold for equivalent elements")
+	}
+	if !Equal[int](Custom(func(a, b int) bool { return a%2 < b%2 }), 2, 4) {
+		t.Error("Equal should hold for elements the comparator can't tell apart")
+	}
+}
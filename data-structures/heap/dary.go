@@ -0,0 +1,112 @@
+package heap
+
+import (
+	"fmt"
+	"iter"
+
+	alg "github.com/lucasturci/everything-go/algorithms"
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+	"github.com/lucasturci/everything-go/data-structures/vector"
+)
+
+// DaryHeap is a heap where every node has d children. A wider heap is shallower, so
+// pushing is cheaper and the children of a node share cache lines, at the cost of more
+// comparisons per level when popping. d = 4 is usually faster than a binary heap.
+// The zero value is a binary heap.
+type DaryHeap[T any, C comparator.Comparator[T]] struct {
+	items vector.Vector[T]
+	d     int
+	cmp   C
+}
+
+func NewDary[T any, C comparator.Comparator[T]](d int) DaryHeap[T, C] {
+	if d < 2 {
+		panic(fmt.Sprintf("a d-ary heap needs d >= 2, got %v", d))
+	}
+	return DaryHeap[T, C]{d: d}
+}
+
+func NewDaryWithComparator[T any, C comparator.Comparator[T]](d int, c C) DaryHeap[T, C] {
+	h := NewDary[T, C](d)
+	h.cmp = c
+	return h
+}
+
+func (h DaryHeap[T, C]) Size() int {
+	return h.items.Size()
+}
+
+func (h DaryHeap[T, C]) IsEmpty() bool {
+	return h.items.IsEmpty()
+}
+
+// Values yields the elements in the order they are laid out in the heap, not in
+// priority order
+func (h DaryHeap[T, C]) Values() iter.Seq[T] {
+	return h.items.Values()
+}
+
+func (h DaryHeap[T, C]) Arity() int {
+	if h.d == 0 {
+		return 2
+	}
+	return h.d
+}
+
+// Heapify fixes the subtree rooted at i
+func (h *DaryHeap[T, C]) Heapify(i int) {
+	d := h.Arity()
+	for d*i+1 < h.Size() {
+		// find the smallest child
+		m := d*i + 1
+		for c := m + 1; c < min(d*i+d+1, h.Size()); c++ {
+			if h.cmp.Less(h.items[c], h.items[m]) {
+				m = c
+			}
+		}
+
+		if h.cmp.Less(h.items[m], h.items[i]) {
+			alg.Swap(&h.items[i], &h.items[m])
+			i = m
+		} else {
+			break
+		}
+	}
+}
+
+// BubbleUp bubbles the element at i up to its correct position
+func (h *DaryHeap[T, C]) BubbleUp(i int) {
+	d := h.Arity()
+	for ; i > 0; i = (i - 1) / d {
+		if h.cmp.Less(h.items[i], h.items[(i-1)/d]) {
+			alg.Swap(&h.items[i], &h.items[(i-1)/d])
+		} else {
+			break
+		}
+	}
+}
+
+func (h *DaryHeap[T, C]) Push(x T) {
+	h.items.PushBack(x)
+	h.BubbleUp(h.Size() - 1)
+}
+
+func (h DaryHeap[T, C]) Top() (val T, err error) {
+	if h.Size() == 0 {
+		return val, ErrEmptyHeap
+	}
+	return h.items[0], nil
+}
+
+func (h *DaryHeap[T, C]) Pop() error {
+	if h.Size() == 0 {
+		return ErrEmptyHeap
+	}
+	alg.Swap(&h.items[0], &h.items[h.Size()-1])
+	h.items.PopBack()
+	if h.Size() > 0 {
+		h.Heapify(0)
+	}
+
+	return nil
+}
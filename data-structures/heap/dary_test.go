@@ -0,0 +1,77 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+func TestDaryHeap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, d := range []int{2, 3, 4, 8} {
+		h := NewDary[int, comparator.Less[int]](d)
+		var naive []int
+		for i := 0; i < 2000; i++ {
+			if r.Intn(3) > 0 || len(naive) == 0 {
+				x := r.Intn(1000)
+				h.Push(x)
+				naive = append(naive, x)
+				continue
+			}
+			slices.Sort(naive)
+			top, err := h.Top()
+			if err != nil || top != naive[0] {
+				t.Fatalf("d = %d: Top() = %d, %v, want %d", d, top, err, naive[0])
+			}
+			h.Pop()
+			naive = naive[1:]
+		}
+		if h.Size() != len(naive) {
+			t.Errorf("d = %d: expected size %d, got %d", d, len(naive), h.Size())
+		}
+	}
+}
+
+func TestDaryHeapZeroValue(t *testing.T) {
+	var h DaryHeap[int, comparator.Greater[int]]
+	if h.Arity() != 2 {
+		t.Errorf("Expected zero value to be a binary heap, got arity %d", h.Arity())
+	}
+	if err := h.Pop(); err != ErrEmptyHeap {
+		t.Errorf("Expected ErrEmptyHeap, got %v", err)
+	}
+	h.Push(1)
+	h.Push(3)
+	h.Push(2)
+	if top, _ := h.Top(); top != 3 {
+		t.Errorf("Expected top 3, got %d", top)
+	}
+}
+
+func TestDaryHeapInvalidArity(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic with d = 1, but it didn't panic")
+		}
+	}()
+	NewDary[int, comparator.Less[int]](1)
+}
+
+func benchmarkDary(b *testing.B, d int) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		h := NewDary[int, comparator.Less[int]](d)
+		for j := 0; j < 100000; j++ {
+			h.Push(r.Int())
+		}
+		for h.Size() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkDaryHeap2(b *testing.B) { benchmarkDary(b, 2) }
+func BenchmarkDaryHeap4(b *testing.B) { benchmarkDary(b, 4) }
+func BenchmarkDaryHeap8(b *testing.B) { benchmarkDary(b, 8) }
@@ -0,0 +1,193 @@
+package heap
+
+import "github.com/lucasturci/everything-go/data-structures/comparator"
+
+// FibonacciHeap is a lazy collection of heap-ordered trees. Push, Meld, Top and
+// DecreaseKey are O(1) amortized and Pop is O(log n) amortized.
+//
+// The roots, and the children of every node, form circular lists linked with prev
+// and next. rank is the number of children of a node and mark tells if it has lost a
+// child since it stopped being a root.
+type FibonacciHeap[T any, C comparator.Comparator[T]] struct {
+	min  *Node[T]
+	size int
+	cmp  C
+}
+
+func NewFibonacciHeap[T any, C comparator.Comparator[T]]() *FibonacciHeap[T, C] {
+	return &FibonacciHeap[T, C]{}
+}
+
+func NewFibonacciHeapWithComparator[T any, C comparator.Comparator[T]](c C) *FibonacciHeap[T, C] {
+	return &FibonacciHeap[T, C]{cmp: c}
+}
+
+func (h *FibonacciHeap[T, C]) Size() int {
+	return h.size
+}
+
+func (h *FibonacciHeap[T, C]) IsEmpty() bool {
+	return h.size == 0
+}
+
+// splice joins two circular lists and returns one of their nodes
+func splice[T any](a, b *Node[T]) *Node[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	an, bp := a.next, b.prev
+	a.next, b.prev = b, a
+	bp.next, an.prev = an, bp
+	return a
+}
+
+// unlink removes n from its circular list and returns another node of the list
+func unlink[T any](n *Node[T]) *Node[T] {
+	var rest *Node[T]
+	if n.next != n {
+		rest = n.next
+		n.prev.next = n.next
+		n.next.prev = n.prev
+	}
+	n.prev, n.next = n, n
+	return rest
+}
+
+// addRoot adds the circular list starting at n to the roots
+func (h *FibonacciHeap[T, C]) addRoot(n *Node[T]) {
+	if n == nil {
+		return
+	}
+	min := h.min
+	h.min = splice(h.min, n)
+	if min != nil && !h.cmp.Less(n.val, min.val) {
+		h.min = min
+	} else {
+		h.min = n
+	}
+}
+
+func (h *FibonacciHeap[T, C]) Push(x T) *Node[T] {
+	n := &Node[T]{val: x}
+	n.prev, n.next = n, n
+	h.addRoot(n)
+	h.size++
+	return n
+}
+
+func (h *FibonacciHeap[T, C]) Top() (val T, err error) {
+	if h.IsEmpty() {
+		return val, ErrEmptyHeap
+	}
+	return h.min.val, nil
+}
+
+func (h *FibonacciHeap[T, C]) Pop() error {
+	if h.IsEmpty() {
+		return ErrEmptyHeap
+	}
+	z := h.min
+	for c := z.child; c != nil && c.parent != nil; c = c.next {
+		c.parent = nil
+		c.mark = false
+	}
+	roots := splice(unlink(z), z.child)
+	z.child = nil
+	z.popped = true
+	h.size--
+	h.min = nil
+	h.consolidate(roots)
+	return nil
+}
+
+// consolidate links the roots of the same rank until all of them have different
+// ranks, then finds the new minimum
+func (h *FibonacciHeap[T, C]) consolidate(roots *Node[T]) {
+	if roots == nil {
+		return
+	}
+	var list []*Node[T]
+	for n := roots; ; n = n.next {
+		list = append(list, n)
+		if n.next == roots {
+			break
+		}
+	}
+
+	var byRank []*Node[T]
+	for _, x := range list {
+		unlink(x)
+		for x.rank < len(byRank) && byRank[x.rank] != nil {
+			y := byRank[x.rank]
+			byRank[x.rank] = nil
+			if h.cmp.Less(y.val, x.val) {
+				x, y = y, x
+			}
+			// make y a child of x
+			y.parent = x
+			y.mark = false
+			x.child = splice(x.child, y)
+			x.rank++
+		}
+		for len(byRank) <= x.rank {
+			byRank = append(byRank, nil)
+		}
+		byRank[x.rank] = x
+	}
+
+	for _, x := range byRank {
+		if x != nil {
+			h.addRoot(x)
+		}
+	}
+}
+
+func (h *FibonacciHeap[T, C]) DecreaseKey(n *Node[T], x T) error {
+	if n == nil || n.popped {
+		return ErrInvalidHandle
+	}
+	if h.cmp.Less(n.val, x) {
+		return ErrIncreaseKey
+	}
+	n.val = x
+	if p := n.parent; p != nil && h.cmp.Less(n.val, p.val) {
+		h.cut(n)
+		// cascading cut: a node that loses a second child is cut as well
+		for p.parent != nil {
+			if !p.mark {
+				p.mark = true
+				break
+			}
+			next := p.parent
+			h.cut(p)
+			p = next
+		}
+	}
+	if h.cmp.Less(n.val, h.min.val) {
+		h.min = n
+	}
+	return nil
+}
+
+// cut moves n from the children of its parent to the roots
+func (h *FibonacciHeap[T, C]) cut(n *Node[T]) {
+	p := n.parent
+	p.child = unlink(n)
+	p.rank--
+	n.parent = nil
+	n.mark = false
+	h.addRoot(n)
+}
+
+func (h *FibonacciHeap[T, C]) Meld(other *FibonacciHeap[T, C]) {
+	if h == other || other.min == nil {
+		return
+	}
+	h.addRoot(other.min)
+	h.size += other.size
+	other.min = nil
+	other.size = 0
+}
@@ -2,6 +2,8 @@ package heap
 
 import (
 	"errors"
+	"fmt"
+	"iter"
 
 	alg "github.com/lucasturci/everything-go/algorithms"
 	"github.com/lucasturci/everything-go/data-structures/comparator"
@@ -41,6 +43,42 @@ func NewWithCapacity[T any, C comparator.Comparator[T]](capacity int) Heap[T, C]
 	return Heap[T, C]{Vector: vector.NewWithCapacity[T](capacity)}
 }
 
+// NewWithComparator is for comparators that need to be configured, like the ones
+// built by comparator.By or comparator.Custom, whose zero value can't be used
+func NewWithComparator[T any, C comparator.Comparator[T]](c C) Heap[T, C] {
+	return Heap[T, C]{cmp: c}
+}
+
+// FromSlice builds a heap out of the elements of s in O(n). The heap takes ownership
+// of s, which is reordered in place.
+func FromSlice[T any, C comparator.Comparator[T]](s []T) Heap[T, C] {
+	var c C
+	return FromSliceWithComparator(s, c)
+}
+
+// FromSliceWithComparator is FromSlice for comparators that need to be configured
+func FromSliceWithComparator[T any, C comparator.Comparator[T]](s []T, c C) Heap[T, C] {
+	h := Heap[T, C]{Vector: vector.NewWithElements(s), cmp: c}
+	h.Init()
+	return h
+}
+
+func FromSeq[T any, C comparator.Comparator[T]](seq iter.Seq[T]) Heap[T, C] {
+	return FromSlice[T, C](vector.Collect(seq))
+}
+
+func FromSeqWithComparator[T any, C comparator.Comparator[T]](seq iter.Seq[T], c C) Heap[T, C] {
+	return FromSliceWithComparator(vector.Collect(seq), c)
+}
+
+// Init restores the heap invariant over all the elements in O(n), heapifying the
+// subtrees bottom-up (Floyd's construction). Use it after modifying the Vector directly.
+func (h *Heap[T, C]) Init() {
+	for i := h.Size()/2 - 1; i >= 0; i-- {
+		h.Heapify(i)
+	}
+}
+
 // Heapify fixes the subtree rooted at i
 func (h *Heap[T, C]) Heapify(i int) {
 	for (i<<1)+1 < h.Size() {
@@ -104,3 +142,72 @@ func (h *Heap[T, C]) Set(i int, x T) {
 	h.Heapify(i)
 	h.BubbleUp(i)
 }
+
+// PushAll pushes every element of xs. When xs is large compared to the heap, the
+// heap is rebuilt in O(n) instead of pushing one element at a time.
+func (h *Heap[T, C]) PushAll(xs ...T) {
+	if len(xs) > h.Size() {
+		h.Vector = append(h.Vector, xs...)
+		h.Init()
+		return
+	}
+	for _, x := range xs {
+		h.Push(x)
+	}
+}
+
+// PopN pops the k elements at the top of the heap, or all of them if there are fewer,
+// and returns them in the order they were popped
+func (h *Heap[T, C]) PopN(k int) vector.Vector[T] {
+	ans := vector.NewWithCapacity[T](min(max(k, 0), h.Size()))
+	for ; k > 0 && h.Size() > 0; k-- {
+		ans.PushBack(h.Get(0))
+		h.Pop()
+	}
+	return ans
+}
+
+// PushPop pushes x and then pops the top of the heap, returning it. It is faster than
+// a Push followed by a Pop, and x itself is returned if it would be the new top.
+func (h *Heap[T, C]) PushPop(x T) T {
+	if h.Size() == 0 || !h.cmp.Less(h.Vector[0], x) {
+		return x
+	}
+	x, h.Vector[0] = h.Vector[0], x
+	h.Heapify(0)
+	return x
+}
+
+// Replace pops the top of the heap and then pushes x, returning the popped element
+func (h *Heap[T, C]) Replace(x T) (top T, err error) {
+	if h.Size() == 0 {
+		return top, ErrEmptyHeap
+	}
+	top, h.Vector[0] = h.Vector[0], x
+	h.Heapify(0)
+	return top, nil
+}
+
+// Drain pops the elements of the heap while yielding them, so they come in priority
+// order. Breaking out of the loop leaves the rest in the heap.
+func (h *Heap[T, C]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for h.Size() > 0 {
+			top := h.Get(0)
+			h.Pop()
+			if !yield(top) {
+				return
+			}
+		}
+	}
+}
+
+// Validate checks the heap invariant, for debugging
+func (h Heap[T, C]) Validate() error {
+	for i := 1; i < h.Size(); i++ {
+		if h.cmp.Less(h.Vector[i], h.Vector[(i-1)>>1]) {
+			return fmt.Errorf("heap invariant violated: element at %v comes before its parent at %v", i, (i-1)>>1)
+		}
+	}
+	return nil
+}
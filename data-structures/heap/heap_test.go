@@ -1,7 +1,11 @@
 package heap
 
 import (
+	"math/rand"
+	"slices"
 	"testing"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
 )
 
 func TestHeapPushPop(t *testing.T) {
@@ -90,3 +94,94 @@ func TestMaxHeap(t *testing.T) {
 		t.Errorf("Expected root to be 5 after pop, got %d", h.Get(0))
 	}
 }
+
+func TestFromSlice(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := make([]int, 1000)
+	for i := range s {
+		s[i] = r.Intn(100)
+	}
+	want := slices.Sorted(slices.Values(s))
+
+	h := FromSlice[int, comparator.Less[int]](slices.Clone(s))
+	if err := h.Validate(); err != nil {
+		t.Fatalf("Unexpected error on Validate: %v", err)
+	}
+	if got := slices.Collect(h.Drain()); !slices.Equal(got, want) {
+		t.Errorf("Drain() did not yield the elements in order: %v", got)
+	}
+	if !h.IsEmpty() {
+		t.Error("Expected heap to be empty after Drain")
+	}
+
+	h = FromSeq[int, comparator.Less[int]](slices.Values(s))
+	if got := h.PopN(10); !slices.Equal(got, want[:10]) {
+		t.Errorf("PopN(10) = %v, want %v", got, want[:10])
+	}
+	if h.Size() != 990 {
+		t.Errorf("Expected size 990 after PopN, got %d", h.Size())
+	}
+	if got := h.PopN(5000); !slices.Equal(got, want[10:]) {
+		t.Error("PopN past the size should pop every element in order")
+	}
+}
+
+func TestFromSliceWithComparator(t *testing.T) {
+	byLength := comparator.By(func(s string) int { return len(s) }, comparator.Less[int]{})
+	h := FromSliceWithComparator([]string{"ccc", "a", "dddd", "bb"}, byLength)
+	if top, _ := h.Top(); top != "a" {
+		t.Errorf("Expected top a, got %s", top)
+	}
+
+	desc := comparator.Custom(func(a, b int) bool { return a > b })
+	g := FromSeqWithComparator(slices.Values([]int{3, 9, 1}), desc)
+	if got := slices.Collect(g.Drain()); !slices.Equal(got, []int{9, 3, 1}) {
+		t.Errorf("Drain() = %v, want [9 3 1]", got)
+	}
+}
+
+func TestPushAll(t *testing.T) {
+	h := NewWithComparator[int](comparator.Custom(func(a, b int) bool { return a > b }))
+	h.PushAll(5, 1, 9)
+	h.PushAll(7)
+	h.PushAll(3, 8, 2, 6, 4)
+	if err := h.Validate(); err != nil {
+		t.Fatalf("Unexpected error on Validate: %v", err)
+	}
+	if got := slices.Collect(h.Drain()); !slices.Equal(got, []int{9, 8, 7, 6, 5, 4, 3, 2, 1}) {
+		t.Errorf("Expected elements in decreasing order, got %v", got)
+	}
+}
+
+func TestPushPopAndReplace(t *testing.T) {
+	h := NewMinHeap[int]()
+	if got := h.PushPop(4); got != 4 || h.Size() != 0 {
+		t.Errorf("PushPop on an empty heap should return its argument, got %d", got)
+	}
+	if _, err := h.Replace(4); err != ErrEmptyHeap {
+		t.Errorf("Expected ErrEmptyHeap, got %v", err)
+	}
+
+	h.PushAll(3, 5, 7)
+	if got := h.PushPop(1); got != 1 {
+		t.Errorf("PushPop(1) = %d, want 1", got)
+	}
+	if got := h.PushPop(6); got != 3 {
+		t.Errorf("PushPop(6) = %d, want 3", got)
+	}
+	if got, _ := h.Replace(2); got != 5 {
+		t.Errorf("Replace(2) = %d, want 5", got)
+	}
+	if got := slices.Collect(h.Drain()); !slices.Equal(got, []int{2, 6, 7}) {
+		t.Errorf("Expected [2 6 7], got %v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	h := NewMinHeap[int]()
+	h.PushAll(1, 2, 3)
+	h.Vector[0] = 10
+	if err := h.Validate(); err == nil {
+		t.Error("Expected Validate to find the broken invariant")
+	}
+}
@@ -0,0 +1,218 @@
+package heap
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	alg "github.com/lucasturci/everything-go/algorithms"
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+	"github.com/lucasturci/everything-go/data-structures/vector"
+)
+
+var ErrInvalidHandle = errors.New("handle does not refer to an element of the heap")
+
+// Handle identifies an element pushed to an IndexedHeap, wherever it moves inside the
+// heap. The slot of a removed element is reused by later pushes, but with a new
+// generation, so the handle of a removed element stays invalid. The zero Handle is
+// never valid.
+type Handle struct {
+	slot int
+	gen  uint64
+}
+
+// handleSlot is where a handle points to: the index of its element in items, or -1
+// while the slot is free
+type handleSlot struct {
+	pos int
+	gen uint64
+}
+
+type indexedItem[T any] struct {
+	val  T
+	slot int
+}
+
+// IndexedHeap is a binary heap that can update or remove any of its elements in
+// O(log n), given the handle returned when it was pushed. It is the heap to use for
+// Dijkstra and Prim, where the priority of queued elements decreases.
+type IndexedHeap[T any, C comparator.Comparator[T]] struct {
+	items vector.Vector[indexedItem[T]]
+	slots vector.Vector[handleSlot]
+	free  vector.Vector[int] // slots that can be reused
+	cmp   C
+}
+
+func NewIndexed[T any, C comparator.Comparator[T]]() IndexedHeap[T, C] {
+	return IndexedHeap[T, C]{}
+}
+
+func NewIndexedWithComparator[T any, C comparator.Comparator[T]](c C) IndexedHeap[T, C] {
+	return IndexedHeap[T, C]{cmp: c}
+}
+
+func NewIndexedMinHeap[T cmp.Ordered]() IndexedHeap[T, comparator.Less[T]] {
+	return IndexedHeap[T, comparator.Less[T]]{}
+}
+
+func NewIndexedMaxHeap[T cmp.Ordered]() IndexedHeap[T, comparator.Greater[T]] {
+	return IndexedHeap[T, comparator.Greater[T]]{}
+}
+
+func (h IndexedHeap[T, C]) Size() int {
+	return h.items.Size()
+}
+
+func (h IndexedHeap[T, C]) IsEmpty() bool {
+	return h.items.IsEmpty()
+}
+
+// Reserve makes room for a total of n elements without reallocating
+func (h *IndexedHeap[T, C]) Reserve(n int) {
+	h.items = slices.Grow(h.items, max(n-h.Size(), 0))
+	h.slots = slices.Grow(h.slots, max(n-h.slots.Size(), 0))
+}
+
+func (h IndexedHeap[T, C]) Contains(handle Handle) bool {
+	if handle.slot < 0 || handle.slot >= h.slots.Size() {
+		return false
+	}
+	s := h.slots[handle.slot]
+	return s.gen == handle.gen && s.pos >= 0
+}
+
+// pos returns the index in items of the element of a valid handle
+func (h IndexedHeap[T, C]) pos(handle Handle) int {
+	return h.slots[handle.slot].pos
+}
+
+func (h *IndexedHeap[T, C]) swap(i, j int) {
+	alg.Swap(&h.items[i], &h.items[j])
+	h.slots[h.items[i].slot].pos = i
+	h.slots[h.items[j].slot].pos = j
+}
+
+func (h *IndexedHeap[T, C]) less(i, j int) bool {
+	return h.cmp.Less(h.items[i].val, h.items[j].val)
+}
+
+func (h *IndexedHeap[T, C]) heapify(i int) {
+	for (i<<1)+1 < h.Size() {
+		l := (i << 1) + 1
+		r := l + 1
+		if r < h.Size() && h.less(r, l) { // make l be the smallest
+			alg.Swap(&l, &r)
+		}
+
+		if h.less(l, i) {
+			h.swap(i, l)
+			i = l
+		} else {
+			break
+		}
+	}
+}
+
+func (h *IndexedHeap[T, C]) bubbleUp(i int) {
+	for ; i > 0; i = (i - 1) >> 1 {
+		if h.less(i, (i-1)>>1) {
+			h.swap(i, (i-1)>>1)
+		} else {
+			break
+		}
+	}
+}
+
+func (h *IndexedHeap[T, C]) Push(x T) Handle {
+	var slot int
+	if h.free.IsEmpty() {
+		slot = h.slots.Size()
+		h.slots.PushBack(handleSlot{gen: 1})
+	} else {
+		slot = h.free[h.free.Size()-1]
+		h.free.PopBack()
+	}
+	h.slots[slot].pos = h.Size()
+	h.items.PushBack(indexedItem[T]{x, slot})
+	h.bubbleUp(h.Size() - 1)
+	return Handle{slot, h.slots[slot].gen}
+}
+
+func (h IndexedHeap[T, C]) Top() (val T, err error) {
+	if h.IsEmpty() {
+		return val, ErrEmptyHeap
+	}
+	return h.items[0].val, nil
+}
+
+// TopHandle returns the handle of the element at the top of the heap
+func (h IndexedHeap[T, C]) TopHandle() (Handle, error) {
+	if h.IsEmpty() {
+		return Handle{}, ErrEmptyHeap
+	}
+	return h.handle(0), nil
+}
+
+func (h IndexedHeap[T, C]) handle(i int) Handle {
+	slot := h.items[i].slot
+	return Handle{slot, h.slots[slot].gen}
+}
+
+func (h *IndexedHeap[T, C]) Pop() error {
+	if h.IsEmpty() {
+		return ErrEmptyHeap
+	}
+	return h.Remove(h.handle(0))
+}
+
+func (h IndexedHeap[T, C]) Get(handle Handle) (val T, err error) {
+	if !h.Contains(handle) {
+		return val, ErrInvalidHandle
+	}
+	return h.items[h.pos(handle)].val, nil
+}
+
+// Update replaces the element of the given handle with x and moves it to its new
+// position. It covers both decrease-key and increase-key.
+func (h *IndexedHeap[T, C]) Update(handle Handle, x T) error {
+	if !h.Contains(handle) {
+		return ErrInvalidHandle
+	}
+	i := h.pos(handle)
+	h.items[i].val = x
+	h.heapify(i)
+	h.bubbleUp(i)
+	return nil
+}
+
+func (h *IndexedHeap[T, C]) Remove(handle Handle) error {
+	if !h.Contains(handle) {
+		return ErrInvalidHandle
+	}
+	i := h.pos(handle)
+	last := h.Size() - 1
+	h.swap(i, last)
+	h.items.PopBack()
+	// bumping the generation invalidates handle before the slot is reused
+	h.slots[handle.slot] = handleSlot{pos: -1, gen: handle.gen + 1}
+	h.free.PushBack(handle.slot)
+	if i < h.Size() {
+		h.heapify(i)
+		h.bubbleUp(i)
+	}
+	return nil
+}
+
+// Iterations
+
+// Values yields the elements in the order they are laid out in the heap, not in
+// priority order
+func (h IndexedHeap[T, C]) Values() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, item := range h.items {
+			if !yield(item.val) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestIndexedHeapPushPop(t *testing.T) {
+	h := NewIndexedMinHeap[int]()
+	for _, x := range []int{5, 3, 7, 1} {
+		h.Push(x)
+	}
+
+	var got []int
+	for !h.IsEmpty() {
+		top, _ := h.Top()
+		got = append(got, top)
+		h.Pop()
+	}
+	if !slices.Equal(got, []int{1, 3, 5, 7}) {
+		t.Errorf("Expected [1 3 5 7], got %v", got)
+	}
+
+	if err := h.Pop(); err != ErrEmptyHeap {
+		t.Errorf("Expected ErrEmptyHeap, got %v", err)
+	}
+}
+
+func TestIndexedHeapHandles(t *testing.T) {
+	h := NewIndexedMaxHeap[int]()
+	a := h.Push(5)
+	b := h.Push(3)
+	c := h.Push(7)
+
+	if top, _ := h.TopHandle(); top != c {
+		t.Errorf("Expected top handle %v, got %v", c, top)
+	}
+
+	// increase key
+	if err := h.Update(b, 10); err != nil {
+		t.Errorf("Unexpected error on Update: %v", err)
+	}
+	if top, _ := h.Top(); top != 10 {
+		t.Errorf("Expected top 10 after update, got %d", top)
+	}
+
+	// decrease key
+	h.Update(b, 1)
+	if top, _ := h.Top(); top != 7 {
+		t.Errorf("Expected top 7 after update, got %d", top)
+	}
+
+	if err := h.Remove(c); err != nil {
+		t.Errorf("Unexpected error on Remove: %v", err)
+	}
+	if h.Contains(c) {
+		t.Error("Removed handle should not be contained in the heap")
+	}
+	if val, _ := h.Get(a); val != 5 {
+		t.Errorf("Expected value 5 for handle a, got %d", val)
+	}
+
+	// d reuses the slot of c, which must not make c valid again
+	d := h.Push(4)
+	for _, handle := range []Handle{c, {}, {slot: 100, gen: 1}} {
+		if err := h.Update(handle, 0); err != ErrInvalidHandle {
+			t.Errorf("Expected ErrInvalidHandle on Update(%v), got %v", handle, err)
+		}
+		if err := h.Remove(handle); err != ErrInvalidHandle {
+			t.Errorf("Expected ErrInvalidHandle on Remove(%v), got %v", handle, err)
+		}
+		if _, err := h.Get(handle); err != ErrInvalidHandle {
+			t.Errorf("Expected ErrInvalidHandle on Get(%v), got %v", handle, err)
+		}
+	}
+	if val, _ := h.Get(d); val != 4 {
+		t.Errorf("Expected value 4 for handle d, got %d", val)
+	}
+}
+
+func TestIndexedHeapReusesSlots(t *testing.T) {
+	h := NewIndexedMinHeap[int]()
+	for i := 0; i < 100000; i++ {
+		h.Push(i)
+		h.Pop()
+	}
+	if h.slots.Size() != 1 {
+		t.Errorf("Expected a single slot after push/pop cycles, got %d", h.slots.Size())
+	}
+
+	live := []Handle{h.Push(0), h.Push(1), h.Push(2)}
+	for i := 0; i < 100000; i++ {
+		if err := h.Remove(live[i%3]); err != nil {
+			t.Fatalf("Unexpected error on Remove: %v", err)
+		}
+		live[i%3] = h.Push(i)
+	}
+	if h.Size() != 3 || h.slots.Size() != 3 {
+		t.Errorf("Expected 3 elements in 3 slots, got %d in %d", h.Size(), h.slots.Size())
+	}
+	for _, handle := range live {
+		if !h.Contains(handle) {
+			t.Errorf("Expected handle %v to be valid", handle)
+		}
+	}
+}
+
+func TestIndexedHeapRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := NewIndexedMinHeap[int]()
+	naive := map[Handle]int{}
+
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(4); {
+		case op == 0 || len(naive) == 0:
+			x := r.Intn(1000)
+			naive[h.Push(x)] = x
+		case op == 1:
+			for handle := range naive {
+				x := r.Intn(1000)
+				h.Update(handle, x)
+				naive[handle] = x
+				break
+			}
+		case op == 2:
+			for handle := range naive {
+				h.Remove(handle)
+				delete(naive, handle)
+				break
+			}
+		default:
+			top, _ := h.Top()
+			handle, _ := h.TopHandle()
+			if naive[handle] != top {
+				t.Fatalf("Top handle %d holds %d, but top is %d", handle, naive[handle], top)
+			}
+			for _, x := range naive {
+				if x < top {
+					t.Fatalf("Top is %d, but %d is in the heap", top, x)
+				}
+			}
+			h.Pop()
+			delete(naive, handle)
+		}
+		if h.Size() != len(naive) {
+			t.Fatalf("Expected size %d, got %d", len(naive), h.Size())
+		}
+	}
+}
+
+// Dijkstra on a small graph, the use case the indexed heap is for
+func TestIndexedHeapDijkstra(t *testing.T) {
+	type edge struct{ to, w int }
+	graph := [][]edge{
+		{{1, 4}, {2, 1}},
+		{{3, 1}},
+		{{1, 2}, {3, 5}},
+		{},
+	}
+
+	dist := []int{0, 1 << 30, 1 << 30, 1 << 30}
+	h := NewIndexedMinHeap[int]()
+	handles := make([]Handle, len(graph))
+	vertex := map[Handle]int{}
+	for v := range graph {
+		handles[v] = h.Push(dist[v])
+		vertex[handles[v]] = v
+	}
+
+	for !h.IsEmpty() {
+		top, _ := h.TopHandle()
+		u := vertex[top]
+		h.Pop()
+		for _, e := range graph[u] {
+			if dist[u]+e.w < dist[e.to] && h.Contains(handles[e.to]) {
+				dist[e.to] = dist[u] + e.w
+				h.Update(handles[e.to], dist[e.to])
+			}
+		}
+	}
+
+	if !slices.Equal(dist, []int{0, 3, 1, 4}) {
+		t.Errorf("Expected distances [0 3 1 4], got %v", dist)
+	}
+}
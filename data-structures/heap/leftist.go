@@ -0,0 +1,143 @@
+package heap
+
+import "github.com/lucasturci/everything-go/data-structures/comparator"
+
+// LeftistHeap is a heap-ordered binary tree where the shortest path to a missing
+// child always goes right, so the right spine has O(log n) nodes. Push, Pop, Meld and
+// DecreaseKey are O(log n).
+//
+// child and next are the left and right children of a node, and rank is the length
+// of its right spine.
+type LeftistHeap[T any, C comparator.Comparator[T]] struct {
+	root *Node[T]
+	size int
+	cmp  C
+}
+
+func NewLeftistHeap[T any, C comparator.Comparator[T]]() *LeftistHeap[T, C] {
+	return &LeftistHeap[T, C]{}
+}
+
+func NewLeftistHeapWithComparator[T any, C comparator.Comparator[T]](c C) *LeftistHeap[T, C] {
+	return &LeftistHeap[T, C]{cmp: c}
+}
+
+func (h *LeftistHeap[T, C]) Size() int {
+	return h.size
+}
+
+func (h *LeftistHeap[T, C]) IsEmpty() bool {
+	return h.size == 0
+}
+
+func rank[T any](n *Node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
+
+// fix swaps the children of n if needed to keep it leftist, and updates its rank
+func fix[T any](n *Node[T]) {
+	if rank(n.child) < rank(n.next) {
+		n.child, n.next = n.next, n.child
+	}
+	n.rank = rank(n.next) + 1
+}
+
+// meld merges the right spines of both trees
+func (h *LeftistHeap[T, C]) meld(a, b *Node[T]) *Node[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp.Less(b.val, a.val) {
+		a, b = b, a
+	}
+	a.next = h.meld(a.next, b)
+	a.next.parent = a
+	fix(a)
+	return a
+}
+
+func (h *LeftistHeap[T, C]) setRoot(n *Node[T]) {
+	h.root = n
+	if n != nil {
+		n.parent = nil
+	}
+}
+
+func (h *LeftistHeap[T, C]) Push(x T) *Node[T] {
+	n := &Node[T]{val: x, rank: 1}
+	h.setRoot(h.meld(h.root, n))
+	h.size++
+	return n
+}
+
+func (h *LeftistHeap[T, C]) Top() (val T, err error) {
+	if h.IsEmpty() {
+		return val, ErrEmptyHeap
+	}
+	return h.root.val, nil
+}
+
+func (h *LeftistHeap[T, C]) Pop() error {
+	if h.IsEmpty() {
+		return ErrEmptyHeap
+	}
+	l, r := h.root.child, h.root.next
+	if l != nil {
+		l.parent = nil
+	}
+	if r != nil {
+		r.parent = nil
+	}
+	h.root.child, h.root.next = nil, nil
+	h.root.popped = true
+	h.setRoot(h.meld(l, r))
+	h.size--
+	return nil
+}
+
+func (h *LeftistHeap[T, C]) DecreaseKey(n *Node[T], x T) error {
+	if n == nil || n.popped {
+		return ErrInvalidHandle
+	}
+	if h.cmp.Less(n.val, x) {
+		return ErrIncreaseKey
+	}
+	n.val = x
+	p := n.parent
+	if p == nil || !h.cmp.Less(n.val, p.val) { // still in heap order
+		return nil
+	}
+
+	// cut the subtree of n, fix the ranks of its ancestors and meld it back
+	if p.child == n {
+		p.child = nil
+	} else {
+		p.next = nil
+	}
+	n.parent = nil
+	for q := p; q != nil; q = q.parent {
+		old := q.rank
+		fix(q)
+		if q.rank == old { // the ancestors only depend on the rank of q
+			break
+		}
+	}
+	h.setRoot(h.meld(h.root, n))
+	return nil
+}
+
+func (h *LeftistHeap[T, C]) Meld(other *LeftistHeap[T, C]) {
+	if h == other {
+		return
+	}
+	h.setRoot(h.meld(h.root, other.root))
+	h.size += other.size
+	other.root = nil
+	other.size = 0
+}
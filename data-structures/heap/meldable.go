@@ -0,0 +1,44 @@
+package heap
+
+import (
+	"errors"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+var ErrIncreaseKey = errors.New("new value is greater than the current one")
+
+// Meldable is implemented by the heaps that can be merged with another heap of the
+// same kind faster than pushing every element. H is the type of the heap itself.
+//
+// Push returns the node holding the element, which stays valid until the element is
+// popped and is used as a handle for DecreaseKey. Meld moves every element of other
+// into the heap, leaving other empty, and the nodes of other remain valid handles.
+type Meldable[T any, H any] interface {
+	Push(x T) *Node[T]
+	Top() (T, error)
+	Pop() error
+	DecreaseKey(n *Node[T], x T) error
+	Meld(other H)
+	Size() int
+	IsEmpty() bool
+}
+
+var (
+	_ Meldable[int, *PairingHeap[int, comparator.Less[int]]]   = &PairingHeap[int, comparator.Less[int]]{}
+	_ Meldable[int, *LeftistHeap[int, comparator.Less[int]]]   = &LeftistHeap[int, comparator.Less[int]]{}
+	_ Meldable[int, *FibonacciHeap[int, comparator.Less[int]]] = &FibonacciHeap[int, comparator.Less[int]]{}
+)
+
+// Node is an element of a node based heap. Each heap uses the links in its own way.
+type Node[T any] struct {
+	val                       T
+	parent, child, prev, next *Node[T]
+	rank                      int
+	mark                      bool
+	popped                    bool
+}
+
+func (n *Node[T]) Value() T {
+	return n.val
+}
@@ -0,0 +1,250 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+type intLess = comparator.Less[int]
+
+func TestMeldableHeaps(t *testing.T) {
+	t.Run("pairing", testMeldable(NewPairingHeap[int, intLess]))
+	t.Run("leftist", testMeldable(NewLeftistHeap[int, intLess]))
+	t.Run("fibonacci", testMeldable(NewFibonacciHeap[int, intLess]))
+}
+
+// testMeldable runs the same tests on any kind of meldable heap
+func testMeldable[H Meldable[int, H]](newHeap func() H) func(t *testing.T) {
+	return func(t *testing.T) {
+		t.Run("push pop", func(t *testing.T) { testMeldablePushPop(t, newHeap) })
+		t.Run("decrease key", func(t *testing.T) { testMeldableDecreaseKey(t, newHeap) })
+		t.Run("meld", func(t *testing.T) { testMeldableMeld(t, newHeap) })
+		t.Run("random operations", func(t *testing.T) { testMeldableRandomOperations(t, newHeap) })
+	}
+}
+
+func testMeldablePushPop[H Meldable[int, H]](t *testing.T, newHeap func() H) {
+	h := newHeap()
+	if err := h.Pop(); err != ErrEmptyHeap {
+		t.Errorf("Expected ErrEmptyHeap, got %v", err)
+	}
+	if _, err := h.Top(); err != ErrEmptyHeap {
+		t.Errorf("Expected ErrEmptyHeap, got %v", err)
+	}
+
+	for _, x := range []int{5, 3, 7, 1, 3} {
+		h.Push(x)
+	}
+	var got []int
+	for !h.IsEmpty() {
+		top, _ := h.Top()
+		got = append(got, top)
+		h.Pop()
+	}
+	if !slices.Equal(got, []int{1, 3, 3, 5, 7}) {
+		t.Errorf("Expected [1 3 3 5 7], got %v", got)
+	}
+}
+
+func testMeldableDecreaseKey[H Meldable[int, H]](t *testing.T, newHeap func() H) {
+	h := newHeap()
+	nodes := make([]*Node[int], 10)
+	for i := range nodes {
+		nodes[i] = h.Push(10 + i)
+	}
+	h.Pop() // forces the lazy heaps to build their trees
+
+	if err := h.DecreaseKey(nodes[7], 1); err != nil {
+		t.Errorf("Unexpected error on DecreaseKey: %v", err)
+	}
+	if top, _ := h.Top(); top != 1 {
+		t.Errorf("Expected top 1 after DecreaseKey, got %d", top)
+	}
+	if nodes[7].Value() != 1 {
+		t.Errorf("Expected node value 1, got %d", nodes[7].Value())
+	}
+	if err := h.DecreaseKey(nodes[3], 100); err != ErrIncreaseKey {
+		t.Errorf("Expected ErrIncreaseKey, got %v", err)
+	}
+	if err := h.DecreaseKey(nodes[0], 0); err != ErrInvalidHandle {
+		t.Errorf("Expected ErrInvalidHandle for a popped node, got %v", err)
+	}
+}
+
+func testMeldableMeld[H Meldable[int, H]](t *testing.T, newHeap func() H) {
+	a, b := newHeap(), newHeap()
+	for i := 0; i < 10; i++ {
+		a.Push(2 * i)
+	}
+	odd := b.Push(1)
+	for i := 1; i < 10; i++ {
+		b.Push(2*i + 1)
+	}
+
+	a.Meld(b)
+	if a.Size() != 20 || !b.IsEmpty() {
+		t.Errorf("Expected sizes 20 and 0 after Meld, got %d and %d", a.Size(), b.Size())
+	}
+
+	// nodes of the melded heap stay valid
+	a.Pop()
+	a.DecreaseKey(odd, -1)
+	if top, _ := a.Top(); top != -1 {
+		t.Errorf("Expected top -1, got %d", top)
+	}
+
+	a.Meld(newHeap())
+	if a.Size() != 19 {
+		t.Errorf("Expected size 19 after melding an empty heap, got %d", a.Size())
+	}
+}
+
+func testMeldableRandomOperations[H Meldable[int, H]](t *testing.T, newHeap func() H) {
+	r := rand.New(rand.NewSource(1))
+	h := newHeap()
+	naive := map[*Node[int]]bool{}
+
+	for i := 0; i < 20000; i++ {
+		switch op := r.Intn(10); {
+		case op < 4 || len(naive) == 0:
+			naive[h.Push(r.Intn(1000000))] = true
+		case op < 6:
+			other := newHeap()
+			for j := r.Intn(5); j > 0; j-- {
+				naive[other.Push(r.Intn(1000000))] = true
+			}
+			h.Meld(other)
+		case op < 8:
+			for n := range naive {
+				if err := h.DecreaseKey(n, n.Value()-r.Intn(1000)); err != nil {
+					t.Fatalf("Unexpected error on DecreaseKey: %v", err)
+				}
+				break
+			}
+		default:
+			top, _ := h.Top()
+			for n := range naive {
+				if n.Value() < top {
+					t.Fatalf("Top is %d, but %d is in the heap", top, n.Value())
+				}
+			}
+			h.Pop()
+			for n := range naive {
+				if n.popped {
+					delete(naive, n)
+				}
+			}
+		}
+		if h.Size() != len(naive) {
+			t.Fatalf("Expected size %d, got %d", len(naive), h.Size())
+		}
+	}
+}
+
+// Benchmarks
+
+const _meldableBenchSize = 10000
+
+func benchmarkPushPop[H Meldable[int, H]](b *testing.B, newHeap func() H) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		h := newHeap()
+		for j := 0; j < _meldableBenchSize; j++ {
+			h.Push(r.Int())
+		}
+		for !h.IsEmpty() {
+			h.Pop()
+		}
+	}
+}
+
+// benchmarkMeld melds many small heaps into one, popping now and then
+func benchmarkMeld[H Meldable[int, H]](b *testing.B, newHeap func() H) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		h := newHeap()
+		for j := 0; j < _meldableBenchSize/10; j++ {
+			other := newHeap()
+			for k := 0; k < 10; k++ {
+				other.Push(r.Int())
+			}
+			h.Meld(other)
+			if j%10 == 0 {
+				h.Pop()
+			}
+		}
+	}
+}
+
+// benchmarkDecreaseKey is the pattern of Dijkstra: many decreases for every pop
+func benchmarkDecreaseKey[H Meldable[int, H]](b *testing.B, newHeap func() H) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		h := newHeap()
+		nodes := make([]*Node[int], _meldableBenchSize)
+		for j := range nodes {
+			nodes[j] = h.Push(1 << 40)
+		}
+		for !h.IsEmpty() {
+			for k := 0; k < 10; k++ {
+				n := nodes[r.Intn(len(nodes))]
+				if !n.popped {
+					h.DecreaseKey(n, n.Value()-r.Intn(1<<20))
+				}
+			}
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkBinaryHeapPushPop(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		h := NewMinHeap[int]()
+		for j := 0; j < _meldableBenchSize; j++ {
+			h.Push(r.Int())
+		}
+		for !h.IsEmpty() {
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkPairingHeapPushPop(b *testing.B) {
+	benchmarkPushPop(b, NewPairingHeap[int, intLess])
+}
+
+func BenchmarkLeftistHeapPushPop(b *testing.B) {
+	benchmarkPushPop(b, NewLeftistHeap[int, intLess])
+}
+
+func BenchmarkFibonacciHeapPushPop(b *testing.B) {
+	benchmarkPushPop(b, NewFibonacciHeap[int, intLess])
+}
+
+func BenchmarkPairingHeapMeld(b *testing.B) {
+	benchmarkMeld(b, NewPairingHeap[int, intLess])
+}
+
+func BenchmarkLeftistHeapMeld(b *testing.B) {
+	benchmarkMeld(b, NewLeftistHeap[int, intLess])
+}
+
+func BenchmarkFibonacciHeapMeld(b *testing.B) {
+	benchmarkMeld(b, NewFibonacciHeap[int, intLess])
+}
+
+func BenchmarkPairingHeapDecreaseKey(b *testing.B) {
+	benchmarkDecreaseKey(b, NewPairingHeap[int, intLess])
+}
+
+func BenchmarkLeftistHeapDecreaseKey(b *testing.B) {
+	benchmarkDecreaseKey(b, NewLeftistHeap[int, intLess])
+}
+
+func BenchmarkFibonacciHeapDecreaseKey(b *testing.B) {
+	benchmarkDecreaseKey(b, NewFibonacciHeap[int, intLess])
+}
@@ -0,0 +1,164 @@
+package heap
+
+import (
+	"iter"
+	"math/bits"
+
+	alg "github.com/lucasturci/everything-go/algorithms"
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+	"github.com/lucasturci/everything-go/data-structures/vector"
+)
+
+// MinMaxHeap is a double-ended heap: both its smallest and its greatest elements can
+// be read in O(1) and popped in O(log n).
+//
+// It is laid out like a binary heap, but the levels alternate between min levels,
+// starting at the root, and max levels. An element in a min level is the smallest of
+// its subtree, and an element in a max level is the greatest of its subtree.
+type MinMaxHeap[T any, C comparator.Comparator[T]] struct {
+	items vector.Vector[T]
+	cmp   C
+}
+
+func NewMinMax[T any, C comparator.Comparator[T]]() MinMaxHeap[T, C] {
+	return MinMaxHeap[T, C]{}
+}
+
+func NewMinMaxWithComparator[T any, C comparator.Comparator[T]](c C) MinMaxHeap[T, C] {
+	return MinMaxHeap[T, C]{cmp: c}
+}
+
+func (h MinMaxHeap[T, C]) Size() int {
+	return h.items.Size()
+}
+
+func (h MinMaxHeap[T, C]) IsEmpty() bool {
+	return h.items.IsEmpty()
+}
+
+// Values yields the elements in the order they are laid out in the heap, not in
+// priority order
+func (h MinMaxHeap[T, C]) Values() iter.Seq[T] {
+	return h.items.Values()
+}
+
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+func parent(i int) int {
+	return (i - 1) >> 1
+}
+
+// before reports whether the element at i must go above the element at j, in a min
+// level if min is true and in a max level otherwise
+func (h *MinMaxHeap[T, C]) before(i, j int, min bool) bool {
+	if min {
+		return h.cmp.Less(h.items[i], h.items[j])
+	}
+	return h.cmp.Less(h.items[j], h.items[i])
+}
+
+// BubbleUp bubbles the element at i up to its correct position
+func (h *MinMaxHeap[T, C]) BubbleUp(i int) {
+	if i == 0 {
+		return
+	}
+	min := isMinLevel(i)
+	if p := parent(i); h.before(i, p, !min) { // belongs to the levels of its parent
+		alg.Swap(&h.items[i], &h.items[p])
+		i, min = p, !min
+	}
+	// move up through the levels of the same kind
+	for i > 2 {
+		g := parent(parent(i))
+		if !h.before(i, g, min) {
+			break
+		}
+		alg.Swap(&h.items[i], &h.items[g])
+		i = g
+	}
+}
+
+// TrickleDown fixes the subtree rooted at i
+func (h *MinMaxHeap[T, C]) TrickleDown(i int) {
+	min := isMinLevel(i)
+	for {
+		// find the first among the children and grandchildren
+		m := -1
+		for _, c := range [...]int{2*i + 1, 2*i + 2, 4*i + 3, 4*i + 4, 4*i + 5, 4*i + 6} {
+			if c < h.Size() && (m == -1 || h.before(c, m, min)) {
+				m = c
+			}
+		}
+		if m == -1 || !h.before(m, i, min) {
+			return
+		}
+		alg.Swap(&h.items[i], &h.items[m])
+		if m <= 2*i+2 { // a child has no descendants in levels of our kind
+			return
+		}
+		if p := parent(m); h.before(p, m, min) {
+			alg.Swap(&h.items[m], &h.items[p])
+		}
+		i = m
+	}
+}
+
+func (h *MinMaxHeap[T, C]) Push(x T) {
+	h.items.PushBack(x)
+	h.BubbleUp(h.Size() - 1)
+}
+
+// maxIndex returns the index of the greatest element, which is the root when it is
+// alone or the greatest of its children otherwise
+func (h MinMaxHeap[T, C]) maxIndex() int {
+	switch h.Size() {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.cmp.Less(h.items[1], h.items[2]) {
+		return 2
+	}
+	return 1
+}
+
+func (h MinMaxHeap[T, C]) Min() (val T, err error) {
+	if h.Size() == 0 {
+		return val, ErrEmptyHeap
+	}
+	return h.items[0], nil
+}
+
+func (h MinMaxHeap[T, C]) Max() (val T, err error) {
+	if h.Size() == 0 {
+		return val, ErrEmptyHeap
+	}
+	return h.items[h.maxIndex()], nil
+}
+
+func (h *MinMaxHeap[T, C]) remove(i int) {
+	alg.Swap(&h.items[i], &h.items[h.Size()-1])
+	h.items.PopBack()
+	if i < h.Size() {
+		h.TrickleDown(i)
+	}
+}
+
+func (h *MinMaxHeap[T, C]) PopMin() error {
+	if h.Size() == 0 {
+		return ErrEmptyHeap
+	}
+	h.remove(0)
+	return nil
+}
+
+func (h *MinMaxHeap[T, C]) PopMax() error {
+	if h.Size() == 0 {
+		return ErrEmptyHeap
+	}
+	h.remove(h.maxIndex())
+	return nil
+}
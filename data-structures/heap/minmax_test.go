@@ -0,0 +1,68 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+func TestMinMaxHeap(t *testing.T) {
+	h := NewMinMax[int, comparator.Less[int]]()
+	if _, err := h.Min(); err != ErrEmptyHeap {
+		t.Errorf("Expected ErrEmptyHeap, got %v", err)
+	}
+	if err := h.PopMax(); err != ErrEmptyHeap {
+		t.Errorf("Expected ErrEmptyHeap, got %v", err)
+	}
+
+	for _, x := range []int{5, 3, 7, 1, 9, 4} {
+		h.Push(x)
+	}
+	min, _ := h.Min()
+	max, _ := h.Max()
+	if min != 1 || max != 9 {
+		t.Errorf("Expected min 1 and max 9, got %d and %d", min, max)
+	}
+
+	h.PopMax()
+	h.PopMin()
+	min, _ = h.Min()
+	max, _ = h.Max()
+	if min != 3 || max != 7 {
+		t.Errorf("Expected min 3 and max 7, got %d and %d", min, max)
+	}
+}
+
+func TestMinMaxHeapRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := NewMinMax[int, comparator.Less[int]]()
+	var naive []int
+	for i := 0; i < 5000; i++ {
+		op := r.Intn(4)
+		if op < 2 || len(naive) == 0 {
+			x := r.Intn(1000)
+			h.Push(x)
+			naive = append(naive, x)
+			continue
+		}
+
+		slices.Sort(naive)
+		min, _ := h.Min()
+		max, _ := h.Max()
+		if min != naive[0] || max != naive[len(naive)-1] {
+			t.Fatalf("Expected min %d and max %d, got %d and %d", naive[0], naive[len(naive)-1], min, max)
+		}
+		if op == 2 {
+			h.PopMin()
+			naive = naive[1:]
+		} else {
+			h.PopMax()
+			naive = naive[:len(naive)-1]
+		}
+		if h.Size() != len(naive) {
+			t.Fatalf("Expected size %d, got %d", len(naive), h.Size())
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package heap
+
+import "github.com/lucasturci/everything-go/data-structures/comparator"
+
+// PairingHeap is a heap-ordered multiway tree. Push, Meld and Top are O(1), Pop is
+// O(log n) amortized and DecreaseKey is o(log n) amortized, and it is usually the
+// fastest meldable heap in practice.
+//
+// Every node points to its first child with child, and the children form a list with
+// next. prev points to the previous sibling, or to the parent for the first child.
+type PairingHeap[T any, C comparator.Comparator[T]] struct {
+	root *Node[T]
+	size int
+	cmp  C
+}
+
+func NewPairingHeap[T any, C comparator.Comparator[T]]() *PairingHeap[T, C] {
+	return &PairingHeap[T, C]{}
+}
+
+func NewPairingHeapWithComparator[T any, C comparator.Comparator[T]](c C) *PairingHeap[T, C] {
+	return &PairingHeap[T, C]{cmp: c}
+}
+
+func (h *PairingHeap[T, C]) Size() int {
+	return h.size
+}
+
+func (h *PairingHeap[T, C]) IsEmpty() bool {
+	return h.size == 0
+}
+
+// meld links two trees, making the root with the greater value the first child of the other
+func (h *PairingHeap[T, C]) meld(a, b *Node[T]) *Node[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp.Less(b.val, a.val) {
+		a, b = b, a
+	}
+	b.prev = a
+	b.next = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+func (h *PairingHeap[T, C]) Push(x T) *Node[T] {
+	n := &Node[T]{val: x}
+	h.root = h.meld(h.root, n)
+	h.size++
+	return n
+}
+
+func (h *PairingHeap[T, C]) Top() (val T, err error) {
+	if h.IsEmpty() {
+		return val, ErrEmptyHeap
+	}
+	return h.root.val, nil
+}
+
+func (h *PairingHeap[T, C]) Pop() error {
+	if h.IsEmpty() {
+		return ErrEmptyHeap
+	}
+
+	// two-pass pairing: meld the children in pairs from left to right, then meld the
+	// pairs from right to left
+	var pairs []*Node[T]
+	for a := h.root.child; a != nil; {
+		b := a.next
+		var next *Node[T]
+		if b != nil {
+			next = b.next
+			b.prev, b.next = nil, nil
+		}
+		a.prev, a.next = nil, nil
+		pairs = append(pairs, h.meld(a, b))
+		a = next
+	}
+	var root *Node[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.meld(pairs[i], root)
+	}
+
+	h.root.child = nil
+	h.root.popped = true
+	h.root = root
+	h.size--
+	return nil
+}
+
+func (h *PairingHeap[T, C]) DecreaseKey(n *Node[T], x T) error {
+	if n == nil || n.popped {
+		return ErrInvalidHandle
+	}
+	if h.cmp.Less(n.val, x) {
+		return ErrIncreaseKey
+	}
+	n.val = x
+	if n == h.root {
+		return nil
+	}
+
+	// cut the subtree of n and meld it back with the root
+	if n.prev.child == n {
+		n.prev.child = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+	n.prev, n.next = nil, nil
+	h.root = h.meld(h.root, n)
+	return nil
+}
+
+func (h *PairingHeap[T, C]) Meld(other *PairingHeap[T, C]) {
+	if h == other {
+		return
+	}
+	h.root = h.meld(h.root, other.root)
+	h.size += other.size
+	other.root = nil
+	other.size = 0
+}
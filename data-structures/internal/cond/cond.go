@@ -0,0 +1,80 @@
+// Package cond provides a condition variable whose waits can be cancelled by a
+// context, for the blocking containers of this module
+package cond
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cond is a sync.Cond whose Wait also returns when a context is done. As with
+// sync.Cond, L must be held when calling Wait, and waiters must recheck their
+// condition in a loop.
+type Cond struct {
+	L       sync.Locker
+	c       sync.Cond
+	waiters int
+}
+
+func New(l sync.Locker) *Cond {
+	return &Cond{L: l, c: sync.Cond{L: l}}
+}
+
+// Signal wakes one waiter, if there is any
+func (c *Cond) Signal() {
+	c.c.Signal()
+}
+
+func (c *Cond) Broadcast() {
+	c.c.Broadcast()
+}
+
+// Waiters returns how many goroutines are parked in Wait. L must be held.
+func (c *Cond) Waiters() int {
+	return c.waiters
+}
+
+// wake is run outside of the waiting goroutine, which is why it takes the lock: a
+// broadcast between the check of the condition and the call to Wait would be lost
+func (c *Cond) wake() {
+	c.L.Lock()
+	c.c.Broadcast()
+	c.L.Unlock()
+}
+
+// Wait unlocks L, waits for Signal, Broadcast or ctx to be done, and locks L again.
+// It returns ctx.Err() when ctx is done.
+func (c *Cond) Wait(ctx context.Context) error {
+	return c.WaitTimer(ctx, nil)
+}
+
+// WaitTimer is Wait that also returns when fired receives, with a nil error. A nil
+// fired never receives.
+func (c *Cond) WaitTimer(ctx context.Context, fired <-chan time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, c.wake)
+	if fired != nil {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-fired:
+				c.wake()
+			case <-done:
+			}
+		}()
+	}
+	c.waiters++
+	c.c.Wait()
+	c.waiters--
+	if stop() {
+		return nil
+	}
+	// A Signal may have picked this waiter just as ctx was done. Pass it on, so it
+	// isn't lost for the waiters that can still use it.
+	c.c.Signal()
+	return ctx.Err()
+}
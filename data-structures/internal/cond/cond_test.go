@@ -0,0 +1,131 @@
+package cond
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSignal(t *testing.T) {
+	var mu sync.Mutex
+	c := New(&mu)
+	ready := false
+	done := make(chan error)
+	go func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for !ready {
+			if err := c.Wait(context.Background()); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	// wait for the goroutine to park, so the Signal is what wakes it
+	mu.Lock()
+	for c.Waiters() == 0 {
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+	}
+	ready = true
+	c.Signal()
+	if c.Waiters() != 1 {
+		t.Errorf("Expected 1 waiter, got %d", c.Waiters())
+	}
+	mu.Unlock()
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestWaitCancelled(t *testing.T) {
+	var mu sync.Mutex
+	c := New(&mu)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	mu.Lock()
+	err := c.Wait(ctx)
+	mu.Unlock()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// a done context doesn't wait at all
+	mu.Lock()
+	err = c.Wait(ctx)
+	mu.Unlock()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWaitTimer(t *testing.T) {
+	var mu sync.Mutex
+	c := New(&mu)
+	fired := make(chan time.Time, 1)
+	fired <- time.Time{}
+
+	mu.Lock()
+	err := c.WaitTimer(context.Background(), fired)
+	mu.Unlock()
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+// Run with -race. Each signal must reach a waiter even when other waiters give up at
+// the same time, otherwise a waiter that never gives up would wait forever.
+func TestSignalNotLost(t *testing.T) {
+	var mu sync.Mutex
+	c := New(&mu)
+	tokens := 0
+	const n = 200
+
+	var wg sync.WaitGroup
+	got := make(chan struct{}, 2*n)
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		// a waiter that gives up quickly
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+			defer cancel()
+			mu.Lock()
+			defer mu.Unlock()
+			for tokens == 0 {
+				if c.Wait(ctx) != nil {
+					return
+				}
+			}
+			tokens--
+			got <- struct{}{}
+		}()
+		// a waiter that doesn't give up
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			for tokens == 0 {
+				c.Wait(context.Background())
+			}
+			tokens--
+			got <- struct{}{}
+		}()
+	}
+	for i := 0; i < 2*n; i++ {
+		mu.Lock()
+		tokens++
+		c.Signal()
+		mu.Unlock()
+	}
+	wg.Wait()
+	if len(got) < n || len(got)+tokens != 2*n {
+		t.Errorf("%d tokens were taken and %d are left, out of %d", len(got), tokens, 2*n)
+	}
+}
@@ -0,0 +1,120 @@
+package priority_queue
+
+import (
+	"cmp"
+	"context"
+	"sync"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+	"github.com/lucasturci/everything-go/data-structures/internal/cond"
+)
+
+// Blocking is a priority queue that is safe for concurrent use. Pop waits for a value
+// to be pushed and, when the queue has a capacity, Push waits for room to be made.
+// Both give up when their context is cancelled or the queue is closed.
+type Blocking[V comparable, P any, C comparator.Comparator[P]] struct {
+	mu       sync.Mutex
+	pq       PriorityQueueCustom[V, P, C]
+	capacity int
+	closed   bool
+	// each push wakes one waiting Pop, and each pop one waiting Push
+	notEmpty, notFull *cond.Cond
+}
+
+// NewBlocking returns a blocking queue that pops the highest priority first. A
+// capacity <= 0 means the queue is unbounded.
+func NewBlocking[V comparable, P cmp.Ordered](capacity int) *Blocking[V, P, comparator.Greater[P]] {
+	return NewBlockingCustom[V, P](capacity, comparator.Greater[P]{})
+}
+
+func NewBlockingCustom[V comparable, P any, C comparator.Comparator[P]](capacity int, c C) *Blocking[V, P, C] {
+	b := &Blocking[V, P, C]{
+		pq:       NewCustom[V, P](c),
+		capacity: capacity,
+	}
+	b.notEmpty = cond.New(&b.mu)
+	b.notFull = cond.New(&b.mu)
+	return b
+}
+
+func (b *Blocking[V, P, C]) full() bool {
+	return b.capacity > 0 && b.pq.Size() >= b.capacity
+}
+
+// Push queues value, waiting while the queue is full. Pushing a value that is already
+// queued fails with ErrDuplicate right away.
+func (b *Blocking[V, P, C]) Push(ctx context.Context, value V, priority P) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for {
+		if b.closed {
+			return ErrClosed
+		}
+		if b.pq.Contains(value) {
+			return ErrDuplicate
+		}
+		if !b.full() {
+			break
+		}
+		if err := b.notFull.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	b.pq.Push(value, priority)
+	b.notEmpty.Signal()
+	return nil
+}
+
+// Pop waits until there is a value in the queue and pops it. Values queued before
+// the queue was closed can still be popped, after that Pop returns ErrClosed.
+func (b *Blocking[V, P, C]) Pop(ctx context.Context) (value V, priority P, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.pq.IsEmpty() {
+		if b.closed {
+			return value, priority, ErrClosed
+		}
+		if err = b.notEmpty.Wait(ctx); err != nil {
+			return
+		}
+	}
+	return b.pop()
+}
+
+// TryPop pops a value if there is one, without waiting
+func (b *Blocking[V, P, C]) TryPop() (value V, priority P, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pq.IsEmpty() && b.closed {
+		return value, priority, ErrClosed
+	}
+	return b.pop()
+}
+
+func (b *Blocking[V, P, C]) pop() (value V, priority P, err error) {
+	value, priority, err = b.pq.Pop()
+	if err == nil {
+		b.notFull.Signal()
+	}
+	return
+}
+
+func (b *Blocking[V, P, C]) Size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.pq.Size()
+}
+
+// Close makes every Push fail with ErrClosed, including the ones waiting for room.
+// Pop keeps returning the queued values and fails once they run out. Closing twice
+// does nothing.
+func (b *Blocking[V, P, C]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	b.notEmpty.Broadcast()
+	b.notFull.Broadcast()
+}
@@ -0,0 +1,185 @@
+package priority_queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucasturci/everything-go/data-structures/internal/cond"
+)
+
+func TestBlockingPopWaitsForPush(t *testing.T) {
+	b := NewBlocking[string, int](0)
+	done := make(chan string)
+	go func() {
+		value, _, err := b.Pop(context.Background())
+		if err != nil {
+			t.Errorf("Unexpected error on Pop: %v", err)
+		}
+		done <- value
+	}()
+
+	b.Push(context.Background(), "job", 1)
+	if got := <-done; got != "job" {
+		t.Errorf("Expected job, got %s", got)
+	}
+}
+
+func TestBlockingPopCancelled(t *testing.T) {
+	b := NewBlocking[int, int](0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := b.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestBlockingPushWaitsWhenFull(t *testing.T) {
+	b := NewBlocking[int, int](2)
+	ctx := context.Background()
+	b.Push(ctx, 1, 1)
+	b.Push(ctx, 2, 2)
+
+	// a duplicate fails instead of waiting for room
+	if err := b.Push(ctx, 2, 5); err != ErrDuplicate {
+		t.Errorf("Expected ErrDuplicate on a full queue, got %v", err)
+	}
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := b.Push(short, 3, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- b.Push(ctx, 3, 3)
+	}()
+	if value, _, _ := b.Pop(ctx); value != 2 {
+		t.Errorf("Expected to pop 2, got %d", value)
+	}
+	if err := <-pushed; err != nil {
+		t.Errorf("Unexpected error on Push: %v", err)
+	}
+	if b.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", b.Size())
+	}
+}
+
+func TestBlockingClose(t *testing.T) {
+	b := NewBlocking[int, int](1)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := b.Pop(ctx)
+			errs <- err
+		}()
+	}
+	// both Pops must be parked, otherwise they would see the closed queue without
+	// being woken up by Close
+	parked(&b.mu, b.notEmpty, 2)
+	if len(errs) != 0 {
+		t.Fatal("Expected Pop to wait on an empty queue")
+	}
+	b.Close()
+	b.Close()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != ErrClosed {
+			t.Errorf("Expected ErrClosed for waiting Pop, got %v", err)
+		}
+	}
+
+	if err := b.Push(ctx, 1, 1); err != ErrClosed {
+		t.Errorf("Expected ErrClosed on Push, got %v", err)
+	}
+}
+
+// parked waits until n goroutines are waiting on c
+func parked(mu *sync.Mutex, c *cond.Cond, n int) {
+	mu.Lock()
+	defer mu.Unlock()
+	for c.Waiters() < n {
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+	}
+}
+
+func TestBlockingDrainsAfterClose(t *testing.T) {
+	b := NewBlocking[int, int](0)
+	ctx := context.Background()
+	b.Push(ctx, 1, 1)
+	b.Close()
+	if value, _, err := b.Pop(ctx); err != nil || value != 1 {
+		t.Errorf("Expected to pop 1 after close, got %d, %v", value, err)
+	}
+	if _, _, err := b.TryPop(); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+// Run with -race. Producers and consumers share a small bounded queue, and every
+// pushed value must be popped exactly once.
+func TestBlockingConcurrent(t *testing.T) {
+	const (
+		producers = 4
+		consumers = 4
+		perWorker = 1000
+	)
+	b := NewBlocking[int, int](8)
+	ctx := context.Background()
+
+	var producing sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producing.Add(1)
+		go func() {
+			defer producing.Done()
+			for i := 0; i < perWorker; i++ {
+				if err := b.Push(ctx, p*perWorker+i, i); err != nil {
+					t.Errorf("Unexpected error on Push: %v", err)
+				}
+			}
+		}()
+	}
+
+	var mu sync.Mutex
+	seen := map[int]int{}
+	var consuming sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		consuming.Add(1)
+		go func() {
+			defer consuming.Done()
+			for {
+				value, _, err := b.Pop(ctx)
+				if err == ErrClosed {
+					return
+				}
+				mu.Lock()
+				seen[value]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	producing.Wait()
+	b.Close()
+	consuming.Wait()
+
+	if len(seen) != producers*perWorker {
+		t.Errorf("Expected %d distinct values, got %d", producers*perWorker, len(seen))
+	}
+	for value, count := range seen {
+		if count != 1 {
+			t.Errorf("Value %d was popped %d times", value, count)
+		}
+	}
+}
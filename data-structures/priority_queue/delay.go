@@ -0,0 +1,291 @@
+package priority_queue
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lucasturci/everything-go/data-structures/internal/cond"
+)
+
+// Clock is the source of time of a DelayQueue. Tests can use a ManualClock to control
+// when items become due.
+type Clock interface {
+	Now() time.Time
+	// NewTimer returns a Timer whose channel receives once d has elapsed
+	NewTimer(d time.Duration) Timer
+}
+
+type Timer interface {
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if it already fired.
+	Stop() bool
+}
+
+type systemClock struct{}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (systemClock) Now() time.Time                 { return time.Now() }
+func (systemClock) NewTimer(d time.Duration) Timer { return systemTimer{time.NewTimer(d)} }
+func (t systemTimer) C() <-chan time.Time          { return t.Timer.C }
+
+// SystemClock is the Clock backed by the time package
+var SystemClock Clock = systemClock{}
+
+// ManualClock is a Clock that only moves when Advance or Set is called
+type ManualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+	// created is signalled whenever a timer is created, for BlockUntil
+	created sync.Cond
+}
+
+type manualTimer struct {
+	clock *ManualClock
+	at    time.Time
+	ch    chan time.Time
+}
+
+func NewManualClock(now time.Time) *ManualClock {
+	c := &ManualClock{now: now}
+	c.created.L = &c.mu
+	return c
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &manualTimer{clock: c, at: c.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.ch <- c.now
+		return t
+	}
+	c.timers = append(c.timers, t)
+	c.created.Broadcast()
+	return t
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *manualTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := slices.Index(c.timers, t)
+	if i < 0 {
+		return false
+	}
+	c.timers = slices.Delete(c.timers, i, i+1)
+	return true
+}
+
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(c.now.Add(d))
+}
+
+// Set moves the clock to now. Moving it backwards doesn't fire anything.
+func (c *ManualClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(now)
+}
+
+func (c *ManualClock) set(now time.Time) {
+	c.now = now
+	c.timers = slices.DeleteFunc(c.timers, func(t *manualTimer) bool {
+		if t.at.After(now) {
+			return false
+		}
+		t.ch <- now
+		return true
+	})
+}
+
+// BlockUntil waits until at least n timers are pending, so a test knows the code it
+// drives is waiting on the clock before advancing it
+func (c *ManualClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.created.Wait()
+	}
+}
+
+// DelayHandle identifies an item scheduled on a DelayQueue. The zero DelayHandle is
+// never returned by Schedule.
+type DelayHandle uint64
+
+// DelayQueue holds items until their deadline and hands them out in deadline order.
+// Items with the same deadline come out in the order they were scheduled. It is safe
+// for concurrent use, and a single goroutine waiting on Next replaces a timer per item.
+//
+// It is a PriorityQueueCustom of handles, prioritised by deadline, with the items kept
+// aside so they don't need to be comparable.
+type DelayQueue[T any] struct {
+	mu     sync.Mutex
+	clock  Clock
+	pq     PriorityQueueCustom[DelayHandle, time.Time, earliest]
+	items  map[DelayHandle]T
+	last   DelayHandle
+	closed bool
+	// signalled when an item is scheduled, as it may be due before the one a waiting
+	// Next is timing
+	scheduled *cond.Cond
+}
+
+// earliest orders deadlines from the earliest to the latest
+type earliest struct{}
+
+func (earliest) Less(a, b time.Time) bool {
+	return a.Before(b)
+}
+
+// NewDelayQueue returns a DelayQueue driven by clock, or by SystemClock if clock is nil
+func NewDelayQueue[T any](clock Clock) *DelayQueue[T] {
+	if clock == nil {
+		clock = SystemClock
+	}
+	q := &DelayQueue[T]{
+		clock: clock,
+		pq:    NewCustom[DelayHandle, time.Time](earliest{}),
+		items: map[DelayHandle]T{},
+	}
+	q.scheduled = cond.New(&q.mu)
+	return q
+}
+
+func (q *DelayQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Size()
+}
+
+// Schedule queues item to become due at the given time, which may already have passed.
+// The returned handle can be used to cancel it.
+func (q *DelayQueue[T]) Schedule(item T, at time.Time) (DelayHandle, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return 0, ErrClosed
+	}
+	q.last++
+	q.pq.Push(q.last, at)
+	q.items[q.last] = item
+	q.scheduled.Signal()
+	return q.last, nil
+}
+
+// Cancel removes the item of handle from the queue. It returns false if the item was
+// already handed out or cancelled.
+func (q *DelayQueue[T]) Cancel(handle DelayHandle) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pq.Remove(handle) != nil {
+		return false
+	}
+	delete(q.items, handle)
+	return true
+}
+
+// popDue pops the earliest item if it is due. Otherwise it returns how long until it
+// is, or a negative duration if the queue is empty.
+func (q *DelayQueue[T]) popDue() (item T, wait time.Duration, ok bool) {
+	handle, at, err := q.pq.Top()
+	if err != nil {
+		return item, -1, false
+	}
+	if wait = at.Sub(q.clock.Now()); wait > 0 {
+		return item, wait, false
+	}
+	q.pq.Pop()
+	item = q.items[handle]
+	delete(q.items, handle)
+	return item, 0, true
+}
+
+// Next waits until the earliest item is due and returns it. Closing the queue doesn't
+// drop the scheduled items: Next keeps handing them out as they become due, and
+// returns ErrClosed once there are none left.
+func (q *DelayQueue[T]) Next(ctx context.Context) (item T, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		item, wait, ok := q.popDue()
+		if ok {
+			return item, nil
+		}
+		if wait < 0 && q.closed {
+			return item, ErrClosed
+		}
+
+		var timer Timer
+		var fired <-chan time.Time
+		if wait > 0 {
+			timer = q.clock.NewTimer(wait)
+			fired = timer.C()
+		}
+		err = q.scheduled.WaitTimer(ctx, fired)
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != nil {
+			return item, err
+		}
+	}
+}
+
+// Due yields the items that are already due, without waiting
+func (q *DelayQueue[T]) Due() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			q.mu.Lock()
+			item, _, ok := q.popDue()
+			q.mu.Unlock()
+			if !ok || !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// All yields items as they become due, until ctx is done or the queue is closed and
+// empty
+func (q *DelayQueue[T]) All(ctx context.Context) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			item, err := q.Next(ctx)
+			if err != nil || !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Close makes Schedule fail with ErrClosed. The items already scheduled are still
+// handed out by Next when due, or can be cancelled.
+func (q *DelayQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	// a Next waiting on an empty queue has nothing left to wait for
+	q.scheduled.Broadcast()
+}
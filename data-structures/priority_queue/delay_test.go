@@ -0,0 +1,173 @@
+package priority_queue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestDelayQueueDue(t *testing.T) {
+	clock := NewManualClock(epoch)
+	q := NewDelayQueue[string](clock)
+	q.Schedule("c", epoch.Add(2*time.Second))
+	q.Schedule("a", epoch.Add(time.Second))
+	q.Schedule("b", epoch.Add(time.Second))
+	q.Schedule("late", epoch.Add(time.Hour))
+	q.Schedule("past", epoch.Add(-time.Second))
+
+	if got := slices.Collect(q.Due()); !slices.Equal(got, []string{"past"}) {
+		t.Errorf("Expected only past to be due, got %v", got)
+	}
+	clock.Advance(2 * time.Second)
+	if got := slices.Collect(q.Due()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Expected a, b, c in deadline order, got %v", got)
+	}
+	if q.Size() != 1 {
+		t.Errorf("Expected 1 item left, got %d", q.Size())
+	}
+}
+
+func TestDelayQueueCancel(t *testing.T) {
+	clock := NewManualClock(epoch)
+	q := NewDelayQueue[int](clock)
+	h1, _ := q.Schedule(1, epoch.Add(time.Second))
+	q.Schedule(2, epoch.Add(time.Second))
+
+	if !q.Cancel(h1) {
+		t.Error("Expected Cancel to succeed")
+	}
+	if q.Cancel(h1) {
+		t.Error("Expected second Cancel of the same handle to fail")
+	}
+	clock.Advance(time.Second)
+	if got := slices.Collect(q.Due()); !slices.Equal(got, []int{2}) {
+		t.Errorf("Expected only 2 to be due, got %v", got)
+	}
+}
+
+func TestDelayQueueNext(t *testing.T) {
+	clock := NewManualClock(epoch)
+	q := NewDelayQueue[string](clock)
+	q.Schedule("slow", epoch.Add(time.Minute))
+
+	got := make(chan string)
+	go func() {
+		for item := range q.All(context.Background()) {
+			got <- item
+		}
+		close(got)
+	}()
+
+	// Next is waiting for the minute to pass, an earlier item must wake it up
+	clock.BlockUntil(1)
+	q.Schedule("fast", epoch.Add(time.Second))
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	if item := <-got; item != "fast" {
+		t.Errorf("Expected fast, got %s", item)
+	}
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	if item := <-got; item != "slow" {
+		t.Errorf("Expected slow, got %s", item)
+	}
+
+	q.Close()
+	if _, ok := <-got; ok {
+		t.Error("Expected All to stop after Close")
+	}
+	if _, err := q.Schedule("closed", epoch); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestDelayQueueNextCancelled(t *testing.T) {
+	clock := NewManualClock(epoch)
+	q := NewDelayQueue[int](clock)
+	q.Schedule(1, epoch.Add(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, err := q.Next(ctx)
+		errs <- err
+	}()
+	clock.BlockUntil(1)
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if q.Size() != 1 {
+		t.Errorf("Expected the item to stay scheduled, got size %d", q.Size())
+	}
+}
+
+func TestDelayQueueSystemClock(t *testing.T) {
+	q := NewDelayQueue[int](nil)
+	start := time.Now()
+	q.Schedule(2, start.Add(20*time.Millisecond))
+	q.Schedule(1, start.Add(10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for _, want := range []int{1, 2} {
+		got, err := q.Next(ctx)
+		if err != nil || got != want {
+			t.Fatalf("Expected %d, got %d, %v", want, got, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("Items were handed out early, after %v", elapsed)
+	}
+}
+
+func TestDelayQueueDrainsAfterClose(t *testing.T) {
+	clock := NewManualClock(epoch)
+	q := NewDelayQueue[string](clock)
+	q.Schedule("due", epoch)
+	q.Schedule("later", epoch.Add(time.Minute))
+	cancelled, _ := q.Schedule("cancelled", epoch.Add(time.Hour))
+	q.Close()
+
+	if !q.Cancel(cancelled) {
+		t.Error("Expected Cancel to work after Close")
+	}
+	ctx := context.Background()
+	if item, err := q.Next(ctx); err != nil || item != "due" {
+		t.Errorf("Expected due after Close, got %s, %v", item, err)
+	}
+
+	got := make(chan string)
+	go func() {
+		item, _ := q.Next(ctx)
+		got <- item
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	if item := <-got; item != "later" {
+		t.Errorf("Expected later to be handed out when due, got %s", item)
+	}
+	if _, err := q.Next(ctx); err != ErrClosed {
+		t.Errorf("Expected ErrClosed once the closed queue is empty, got %v", err)
+	}
+}
+
+func TestDelayQueueHandles(t *testing.T) {
+	q := NewDelayQueue[int](NewManualClock(epoch))
+	a, _ := q.Schedule(1, epoch)
+	b, _ := q.Schedule(1, epoch)
+	if a == 0 || a == b {
+		t.Errorf("Expected distinct non-zero handles, got %d and %d", a, b)
+	}
+	if q.Cancel(0) {
+		t.Error("Expected Cancel of the zero handle to fail")
+	}
+	if q.Size() != 2 {
+		t.Errorf("Expected equal items to be scheduled separately, got size %d", q.Size())
+	}
+}
@@ -2,15 +2,181 @@ package priority_queue
 
 import (
 	"cmp"
+	"errors"
+	"iter"
 
 	"github.com/lucasturci/everything-go/data-structures/comparator"
 	"github.com/lucasturci/everything-go/data-structures/heap"
 )
 
-type PriorityQueue[T cmp.Ordered] struct {
-	heap.MaxHeap[T]
+var (
+	ErrEmpty     = errors.New("priority queue is empty")
+	ErrNotFound  = errors.New("value not found in the priority queue")
+	ErrDuplicate = errors.New("value is already in the priority queue")
+	ErrClosed    = errors.New("priority queue is closed")
+)
+
+// Values and their priorities are separate. Before, PriorityQueue[T] ordered values by
+// themselves; New[T, T]() pushing x with priority x gives the same order.
+
+// PriorityQueue pops the value with the highest priority first
+type PriorityQueue[V comparable, P cmp.Ordered] struct {
+	PriorityQueueCustom[V, P, comparator.Greater[P]]
+}
+
+func New[V comparable, P cmp.Ordered]() PriorityQueue[V, P] {
+	return PriorityQueue[V, P]{NewCustom[V, P](comparator.Greater[P]{})}
+}
+
+func NewWithCapacity[V comparable, P cmp.Ordered](capacity int) PriorityQueue[V, P] {
+	return PriorityQueue[V, P]{NewCustomWithCapacity[V, P](capacity, comparator.Greater[P]{})}
+}
+
+// PriorityQueueCustom pops first the value whose priority comes first according to
+// C. Values with equal priorities are popped in the order they were pushed.
+//
+// Each value is queued at most once, so it can be looked up to update or remove it.
+// Pushing a value that is already queued fails with ErrDuplicate: to queue equal jobs
+// separately, give each one a distinct value, e.g. a pointer or an ID.
+type PriorityQueueCustom[V comparable, P any, C comparator.Comparator[P]] struct {
+	heap    heap.IndexedHeap[item[V, P], itemComparator[V, P, C]]
+	handles map[V]heap.Handle
+	seq     uint64
+}
+
+type item[V any, P any] struct {
+	value    V
+	priority P
+	seq      uint64 // breaks ties between equal priorities, so the queue is FIFO
+}
+
+type itemComparator[V any, P any, C comparator.Comparator[P]] struct {
+	c C
+}
+
+func (ic itemComparator[V, P, C]) Less(a, b item[V, P]) bool {
+	if ic.c.Less(a.priority, b.priority) {
+		return true
+	}
+	if ic.c.Less(b.priority, a.priority) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+func NewCustom[V comparable, P any, C comparator.Comparator[P]](c C) PriorityQueueCustom[V, P, C] {
+	return PriorityQueueCustom[V, P, C]{
+		heap:    heap.NewIndexedWithComparator[item[V, P]](itemComparator[V, P, C]{c}),
+		handles: map[V]heap.Handle{},
+	}
+}
+
+func NewCustomWithCapacity[V comparable, P any, C comparator.Comparator[P]](capacity int, c C) PriorityQueueCustom[V, P, C] {
+	pq := NewCustom[V, P](c)
+	pq.heap.Reserve(capacity)
+	pq.handles = make(map[V]heap.Handle, capacity)
+	return pq
+}
+
+func (pq PriorityQueueCustom[V, P, C]) Size() int {
+	return pq.heap.Size()
+}
+
+func (pq PriorityQueueCustom[V, P, C]) IsEmpty() bool {
+	return pq.heap.IsEmpty()
+}
+
+func (pq PriorityQueueCustom[V, P, C]) Contains(value V) bool {
+	_, ok := pq.handles[value]
+	return ok
+}
+
+func (pq *PriorityQueueCustom[V, P, C]) nextItem(value V, priority P) item[V, P] {
+	pq.seq++
+	return item[V, P]{value, priority, pq.seq}
+}
+
+// Push queues value with the given priority. It returns ErrDuplicate, leaving the
+// queue untouched, if value is already queued; UpdatePriority changes its priority.
+func (pq *PriorityQueueCustom[V, P, C]) Push(value V, priority P) error {
+	if pq.handles == nil {
+		pq.handles = map[V]heap.Handle{}
+	}
+	if _, ok := pq.handles[value]; ok {
+		return ErrDuplicate
+	}
+	pq.handles[value] = pq.heap.Push(pq.nextItem(value, priority))
+	return nil
+}
+
+func (pq PriorityQueueCustom[V, P, C]) Top() (value V, priority P, err error) {
+	top, err := pq.heap.Top()
+	if err != nil {
+		return value, priority, ErrEmpty
+	}
+	return top.value, top.priority, nil
+}
+
+func (pq *PriorityQueueCustom[V, P, C]) Pop() (value V, priority P, err error) {
+	value, priority, err = pq.Top()
+	if err != nil {
+		return
+	}
+	pq.heap.Pop()
+	delete(pq.handles, value)
+	return
+}
+
+func (pq PriorityQueueCustom[V, P, C]) Priority(value V) (priority P, err error) {
+	handle, ok := pq.handles[value]
+	if !ok {
+		return priority, ErrNotFound
+	}
+	it, err := pq.heap.Get(handle)
+	return it.priority, err
+}
+
+// UpdatePriority changes the priority of a queued value. The value goes behind the
+// values that already had the new priority.
+func (pq *PriorityQueueCustom[V, P, C]) UpdatePriority(value V, priority P) error {
+	handle, ok := pq.handles[value]
+	if !ok {
+		return ErrNotFound
+	}
+	return pq.heap.Update(handle, pq.nextItem(value, priority))
+}
+
+func (pq *PriorityQueueCustom[V, P, C]) Remove(value V) error {
+	handle, ok := pq.handles[value]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(pq.handles, value)
+	return pq.heap.Remove(handle)
+}
+
+// Iterations
+
+// Values yields every queued value with its priority, in no particular order
+func (pq PriorityQueueCustom[V, P, C]) Values() iter.Seq2[V, P] {
+	return func(yield func(V, P) bool) {
+		for it := range pq.heap.Values() {
+			if !yield(it.value, it.priority) {
+				return
+			}
+		}
+	}
 }
 
-type PriorityQueueCustom[T any, C comparator.Comparator[T]] struct {
-	heap.Heap[T, C]
+// Drain pops the values while yielding them, so they come in priority order.
+// Breaking out of the loop leaves the rest in the queue.
+func (pq *PriorityQueueCustom[V, P, C]) Drain() iter.Seq2[V, P] {
+	return func(yield func(V, P) bool) {
+		for !pq.IsEmpty() {
+			value, priority, _ := pq.Pop()
+			if !yield(value, priority) {
+				return
+			}
+		}
+	}
 }
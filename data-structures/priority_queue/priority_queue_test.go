@@ -0,0 +1,165 @@
+package priority_queue
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+func TestPushPop(t *testing.T) {
+	pq := New[string, int]()
+	pq.Push("low", 1)
+	pq.Push("high", 10)
+	pq.Push("mid", 5)
+
+	if pq.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", pq.Size())
+	}
+
+	value, priority, err := pq.Top()
+	if err != nil || value != "high" || priority != 10 {
+		t.Errorf("Top() = %v, %v, %v, want high, 10, nil", value, priority, err)
+	}
+
+	var got []string
+	for !pq.IsEmpty() {
+		value, _, _ := pq.Pop()
+		got = append(got, value)
+	}
+	if !slices.Equal(got, []string{"high", "mid", "low"}) {
+		t.Errorf("Expected [high mid low], got %v", got)
+	}
+
+	if _, _, err := pq.Pop(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	var pq PriorityQueue[int, int]
+	pq.Push(1, 1)
+	pq.Push(2, 2)
+	if value, _, _ := pq.Top(); value != 2 {
+		t.Errorf("Expected top 2, got %d", value)
+	}
+}
+
+func TestFIFOAmongEqualPriorities(t *testing.T) {
+	pq := NewWithCapacity[int, int](100)
+	for i := 0; i < 100; i++ {
+		pq.Push(i, i%3)
+	}
+
+	var got []int
+	for value := range pq.Drain() {
+		got = append(got, value)
+	}
+	var want []int
+	for p := 2; p >= 0; p-- {
+		for i := p; i < 100; i += 3 {
+			want = append(want, i)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected values of equal priority in push order, got %v", got)
+	}
+}
+
+func TestPushDuplicate(t *testing.T) {
+	pq := New[string, int]()
+	pq.Push("job", 1)
+	if err := pq.Push("job", 5); err != ErrDuplicate {
+		t.Errorf("Expected ErrDuplicate, got %v", err)
+	}
+	if priority, _ := pq.Priority("job"); priority != 1 || pq.Size() != 1 {
+		t.Errorf("Expected a duplicate push to leave the queue untouched, got priority %d and size %d", priority, pq.Size())
+	}
+
+	// once popped, the value can be queued again
+	pq.Pop()
+	if err := pq.Push("job", 5); err != nil {
+		t.Errorf("Unexpected error pushing a popped value again: %v", err)
+	}
+}
+
+func TestUpdatePriority(t *testing.T) {
+	pq := NewCustom[string, int](comparator.Less[int]{})
+	pq.Push("a", 5)
+	pq.Push("b", 3)
+	pq.Push("c", 3)
+
+	if err := pq.UpdatePriority("a", 1); err != nil {
+		t.Errorf("Unexpected error on UpdatePriority: %v", err)
+	}
+	if value, _, _ := pq.Top(); value != "a" {
+		t.Errorf("Expected top a after update, got %s", value)
+	}
+	if p, _ := pq.Priority("a"); p != 1 {
+		t.Errorf("Expected priority 1, got %d", p)
+	}
+
+	// b moves behind c, which already had priority 3
+	pq.UpdatePriority("b", 3)
+	pq.UpdatePriority("a", 4)
+	if pq.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", pq.Size())
+	}
+
+	var got []string
+	for value := range pq.Drain() {
+		got = append(got, value)
+	}
+	if !slices.Equal(got, []string{"c", "b", "a"}) {
+		t.Errorf("Expected [c b a], got %v", got)
+	}
+
+	if err := pq.UpdatePriority("x", 1); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if _, err := pq.Priority("x"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	pq := New[int, int]()
+	pq.Push(1, 1)
+	pq.Push(2, 2)
+	if err := pq.Remove(2); err != nil {
+		t.Errorf("Unexpected error on Remove: %v", err)
+	}
+	if pq.Contains(2) {
+		t.Error("Removed value should not be in the queue")
+	}
+	if err := pq.Remove(2); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if value, _, _ := pq.Top(); value != 1 {
+		t.Errorf("Expected top 1, got %d", value)
+	}
+}
+
+func TestValues(t *testing.T) {
+	pq := New[string, int]()
+	want := map[string]int{"a": 1, "b": 2, "c": 3}
+	for value, priority := range want {
+		pq.Push(value, priority)
+	}
+
+	got := maps.Collect(pq.Values())
+	if !maps.Equal(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+	if pq.Size() != 3 {
+		t.Error("Values() should not modify the queue")
+	}
+
+	for range pq.Drain() {
+		break
+	}
+	if pq.Size() != 2 {
+		t.Errorf("Expected size 2 after breaking out of Drain, got %d", pq.Size())
+	}
+}
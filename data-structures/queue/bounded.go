@@ -0,0 +1,223 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+
+	"github.com/lucasturci/everything-go/data-structures/internal/cond"
+)
+
+var (
+	ErrFull   = errors.New("queue is full")
+	ErrClosed = errors.New("queue is closed")
+)
+
+// Policy decides what a bounded queue does with a push when it is full
+type Policy int
+
+const (
+	// Reject fails the push with ErrFull
+	Reject Policy = iota
+	// OverwriteOldest drops the element at the front to make room
+	OverwriteOldest
+	// Block waits for room. Only a Blocking queue can wait, a Bounded queue treats it
+	// like Reject.
+	Block
+)
+
+// Bounded is a FIFO queue that holds at most a fixed number of elements. Create it
+// with NewBounded: the zero value has no room, so every Push fails with ErrFull.
+type Bounded[T any] struct {
+	d        Deque[T]
+	capacity int
+	policy   Policy
+}
+
+// NewBounded panics if capacity is not positive
+func NewBounded[T any](capacity int, policy Policy) Bounded[T] {
+	if capacity <= 0 {
+		panic("bounded queue capacity must be positive")
+	}
+	return Bounded[T]{capacity: capacity, policy: policy}
+}
+
+func (q Bounded[T]) Size() int {
+	return q.d.Size()
+}
+
+func (q Bounded[T]) IsEmpty() bool {
+	return q.d.IsEmpty()
+}
+
+func (q Bounded[T]) IsFull() bool {
+	return q.d.Size() >= q.capacity
+}
+
+func (q Bounded[T]) Capacity() int {
+	return q.capacity
+}
+
+func (q *Bounded[T]) Push(x T) error {
+	if q.IsFull() {
+		// with no capacity there's nothing to overwrite
+		if q.policy != OverwriteOldest || q.capacity <= 0 {
+			return ErrFull
+		}
+		q.d.PopFront()
+	}
+	q.d.PushBack(x)
+	return nil
+}
+
+func (q *Bounded[T]) Pop() (ret T, err error) {
+	return q.d.PopFront()
+}
+
+func (q Bounded[T]) Front() (ret T, err error) {
+	return q.d.Front()
+}
+
+// Values yields the elements in the order they will be popped
+func (q Bounded[T]) Values() iter.Seq[T] {
+	return q.d.Values()
+}
+
+// Blocking is a Bounded queue that is safe for concurrent use. With the Block policy,
+// Push waits for room, and Pop always waits for an element. Both give up when their
+// context is cancelled or the queue is closed. Create it with NewBlocking, the zero
+// value is not ready to use.
+type Blocking[T any] struct {
+	mu     sync.Mutex
+	q      Bounded[T]
+	closed bool
+	// notEmpty wakes Pop and PopBatch, notFull wakes Push under the Block policy
+	notEmpty, notFull *cond.Cond
+}
+
+// NewBlocking panics if capacity is not positive
+func NewBlocking[T any](capacity int, policy Policy) *Blocking[T] {
+	b := &Blocking[T]{q: NewBounded[T](capacity, policy)}
+	b.notEmpty = cond.New(&b.mu)
+	b.notFull = cond.New(&b.mu)
+	return b
+}
+
+// Push adds x at the back, handling a full queue according to the policy
+func (b *Blocking[T]) Push(ctx context.Context, x T) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.q.policy == Block && b.q.IsFull() && !b.closed {
+		if err := b.notFull.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return b.push(x)
+}
+
+// TryPush is Push without waiting: it fails with ErrFull instead
+func (b *Blocking[T]) TryPush(x T) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.push(x)
+}
+
+func (b *Blocking[T]) push(x T) error {
+	if b.closed {
+		return ErrClosed
+	}
+	if err := b.q.Push(x); err != nil {
+		return err
+	}
+	b.notEmpty.Signal()
+	return nil
+}
+
+// Pop waits for an element and removes it from the front. Elements pushed before the
+// queue was closed can still be popped, after that Pop returns ErrClosed.
+func (b *Blocking[T]) Pop(ctx context.Context) (ret T, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err = b.waitForElements(ctx); err != nil {
+		return
+	}
+	ret, _ = b.q.Pop()
+	b.notFull.Signal()
+	return ret, nil
+}
+
+// TryPop is Pop without waiting: it fails with ErrEmpty instead
+func (b *Blocking[T]) TryPop() (ret T, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.q.IsEmpty() && b.closed {
+		return ret, ErrClosed
+	}
+	if ret, err = b.q.Pop(); err == nil {
+		b.notFull.Signal()
+	}
+	return
+}
+
+// PopBatch waits for at least one element and then pops up to n of them at once
+func (b *Blocking[T]) PopBatch(ctx context.Context, n int) ([]T, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.waitForElements(ctx); err != nil {
+		return nil, err
+	}
+	batch := make([]T, 0, min(n, b.q.Size()))
+	for len(batch) < n && !b.q.IsEmpty() {
+		x, _ := b.q.Pop()
+		batch = append(batch, x)
+		b.notFull.Signal()
+	}
+	return batch, nil
+}
+
+func (b *Blocking[T]) waitForElements(ctx context.Context) error {
+	for b.q.IsEmpty() {
+		if b.closed {
+			return ErrClosed
+		}
+		if err := b.notEmpty.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Front returns the element at the front without removing it
+func (b *Blocking[T]) Front() (ret T, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.q.Front()
+}
+
+func (b *Blocking[T]) Size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.q.Size()
+}
+
+func (b *Blocking[T]) Capacity() int {
+	return b.q.Capacity()
+}
+
+// Close ends the pipeline: pushes fail with ErrClosed from then on, including the ones
+// waiting for room, while pops drain what is left before failing too. Closing twice
+// does nothing.
+func (b *Blocking[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	b.notEmpty.Broadcast()
+	b.notFull.Broadcast()
+}
@@ -0,0 +1,200 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBoundedPolicies(t *testing.T) {
+	tests := []struct {
+		policy   Policy
+		err      error
+		expected []int
+	}{
+		{Reject, ErrFull, []int{1, 2, 3}},
+		{OverwriteOldest, nil, []int{2, 3, 4}},
+		{Block, ErrFull, []int{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		q := NewBounded[int](3, tt.policy)
+		for i := 1; i <= 3; i++ {
+			if err := q.Push(i); err != nil {
+				t.Errorf("Unexpected error pushing %d: %v", i, err)
+			}
+		}
+		if !q.IsFull() {
+			t.Error("Expected queue to be full")
+		}
+		if err := q.Push(4); err != tt.err {
+			t.Errorf("Policy %d: expected %v pushing to a full queue, got %v", tt.policy, tt.err, err)
+		}
+		if got := slices.Collect(q.Values()); !slices.Equal(got, tt.expected) {
+			t.Errorf("Policy %d: expected %v, got %v", tt.policy, tt.expected, got)
+		}
+	}
+}
+
+func TestBlockingPushWaits(t *testing.T) {
+	b := NewBlocking[int](1, Block)
+	ctx := context.Background()
+	b.Push(ctx, 1)
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := b.Push(short, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if err := b.TryPush(2); err != ErrFull {
+		t.Errorf("Expected ErrFull on TryPush, got %v", err)
+	}
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- b.Push(ctx, 2)
+	}()
+	if x, _ := b.Pop(ctx); x != 1 {
+		t.Errorf("Expected to pop 1, got %d", x)
+	}
+	if err := <-pushed; err != nil {
+		t.Errorf("Unexpected error on Push: %v", err)
+	}
+	if x, _ := b.Front(); x != 2 {
+		t.Errorf("Expected front 2, got %d", x)
+	}
+}
+
+func TestBlockingOverwrite(t *testing.T) {
+	b := NewBlocking[int](2, OverwriteOldest)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if err := b.Push(ctx, i); err != nil {
+			t.Errorf("Unexpected error on Push: %v", err)
+		}
+	}
+	batch, _ := b.PopBatch(ctx, 10)
+	if !slices.Equal(batch, []int{3, 4}) {
+		t.Errorf("Expected [3 4], got %v", batch)
+	}
+}
+
+func TestBlockingPopBatch(t *testing.T) {
+	b := NewBlocking[int](10, Block)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		b.Push(ctx, i)
+	}
+	batch, _ := b.PopBatch(ctx, 3)
+	if !slices.Equal(batch, []int{0, 1, 2}) {
+		t.Errorf("Expected [0 1 2], got %v", batch)
+	}
+	if b.Size() != 2 {
+		t.Errorf("Expected 2 elements left, got %d", b.Size())
+	}
+}
+
+func TestBlockingClose(t *testing.T) {
+	b := NewBlocking[int](1, Block)
+	ctx := context.Background()
+	b.Push(ctx, 1)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- b.Push(ctx, 2)
+	}()
+	// the Push must be parked on the full queue, so that only Close can wake it
+	b.mu.Lock()
+	for b.notFull.Waiters() == 0 {
+		b.mu.Unlock()
+		time.Sleep(time.Millisecond)
+		b.mu.Lock()
+	}
+	b.mu.Unlock()
+	if len(errs) != 0 {
+		t.Fatal("Expected Push to wait on a full queue")
+	}
+	b.Close()
+	if err := <-errs; err != ErrClosed {
+		t.Errorf("Expected ErrClosed for the waiting Push, got %v", err)
+	}
+
+	if x, err := b.Pop(ctx); err != nil || x != 1 {
+		t.Errorf("Expected to pop 1 after Close, got %d, %v", x, err)
+	}
+	if _, err := b.Pop(ctx); err != ErrClosed {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+	if _, err := b.TryPop(); err != ErrClosed {
+		t.Errorf("Expected ErrClosed on TryPop, got %v", err)
+	}
+}
+
+// Run with -race. The queue keeps FIFO order per producer.
+func TestBlockingPipeline(t *testing.T) {
+	const (
+		producers = 4
+		perWorker = 2000
+	)
+	b := NewBlocking[[2]int](16, Block)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				b.Push(ctx, [2]int{p, i})
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		b.Close()
+	}()
+
+	next := make([]int, producers)
+	for {
+		batch, err := b.PopBatch(ctx, 5)
+		if err == ErrClosed {
+			break
+		}
+		for _, x := range batch {
+			if x[1] != next[x[0]] {
+				t.Fatalf("Producer %d: expected %d, got %d", x[0], next[x[0]], x[1])
+			}
+			next[x[0]]++
+		}
+	}
+	for p, n := range next {
+		if n != perWorker {
+			t.Errorf("Producer %d: expected %d elements, got %d", p, perWorker, n)
+		}
+	}
+}
+
+func TestBoundedZeroValue(t *testing.T) {
+	var q Bounded[int]
+	if err := q.Push(1); err != ErrFull {
+		t.Errorf("Expected ErrFull on the zero value, got %v", err)
+	}
+	q.policy = OverwriteOldest
+	if err := q.Push(1); err != ErrFull || q.Size() != 0 {
+		t.Errorf("Expected ErrFull and no elements with no capacity, got %v and size %d", err, q.Size())
+	}
+
+	for _, capacity := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected NewBounded(%d) to panic", capacity)
+				}
+			}()
+			NewBounded[int](capacity, Reject)
+		}()
+	}
+}
@@ -0,0 +1,170 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+var ErrEmpty = errors.New("queue is empty")
+
+const _minCapacity = 8
+
+// Deque is a double-ended queue stored in a ring buffer. The zero value is an empty
+// deque ready to use.
+type Deque[T any] struct {
+	buf  []T // len(buf) is 0 or a power of two, so indices wrap with a mask
+	head int
+	n    int
+}
+
+func NewDeque[T any]() Deque[T] {
+	return Deque[T]{}
+}
+
+func NewDequeWithCapacity[T any](capacity int) Deque[T] {
+	c := _minCapacity
+	for c < capacity {
+		c *= 2
+	}
+	return Deque[T]{buf: make([]T, c)}
+}
+
+func (d Deque[T]) Size() int {
+	return d.n
+}
+
+func (d Deque[T]) IsEmpty() bool {
+	return d.n == 0
+}
+
+func (d Deque[T]) Capacity() int {
+	return len(d.buf)
+}
+
+// index maps a position in the deque to a position in buf
+func (d Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// resize moves the elements to a buffer of the given capacity, starting at index 0
+func (d *Deque[T]) resize(capacity int) {
+	buf := make([]T, capacity)
+	if d.n > 0 {
+		if end := d.head + d.n; end <= len(d.buf) {
+			copy(buf, d.buf[d.head:end])
+		} else {
+			k := copy(buf, d.buf[d.head:])
+			copy(buf[k:], d.buf[:d.n-k])
+		}
+	}
+	d.buf, d.head = buf, 0
+}
+
+func (d *Deque[T]) grow() {
+	if d.n == len(d.buf) {
+		d.resize(max(2*len(d.buf), _minCapacity))
+	}
+}
+
+// shrink halves the buffer when it is only a quarter full, so a deque that was once
+// large doesn't hold on to its memory. Halving at a quarter rather than at a half
+// keeps alternating pushes and pops from resizing every time.
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > _minCapacity && 4*d.n <= len(d.buf) {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+func (d *Deque[T]) PushBack(x T) {
+	d.grow()
+	d.buf[d.index(d.n)] = x
+	d.n++
+}
+
+func (d *Deque[T]) PushFront(x T) {
+	d.grow()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = x
+	d.n++
+}
+
+func (d *Deque[T]) PopFront() (ret T, err error) {
+	if d.IsEmpty() {
+		return ret, ErrEmpty
+	}
+	var zero T
+	ret, d.buf[d.head] = d.buf[d.head], zero
+	d.head = d.index(1)
+	d.n--
+	d.shrink()
+	return ret, nil
+}
+
+func (d *Deque[T]) PopBack() (ret T, err error) {
+	if d.IsEmpty() {
+		return ret, ErrEmpty
+	}
+	var zero T
+	i := d.index(d.n - 1)
+	ret, d.buf[i] = d.buf[i], zero
+	d.n--
+	d.shrink()
+	return ret, nil
+}
+
+func (d Deque[T]) Front() (ret T, err error) {
+	if d.IsEmpty() {
+		return ret, ErrEmpty
+	}
+	return d.buf[d.head], nil
+}
+
+func (d Deque[T]) Back() (ret T, err error) {
+	if d.IsEmpty() {
+		return ret, ErrEmpty
+	}
+	return d.buf[d.index(d.n-1)], nil
+}
+
+// At returns the i-th element counting from the front
+func (d Deque[T]) At(i int) (ret T, err error) {
+	if i < 0 || i >= d.n {
+		return ret, fmt.Errorf("index %v out of bounds for deque of size %v", i, d.n)
+	}
+	return d.buf[d.index(i)], nil
+}
+
+func (d *Deque[T]) Set(i int, x T) error {
+	if i < 0 || i >= d.n {
+		return fmt.Errorf("index %v out of bounds for deque of size %v", i, d.n)
+	}
+	d.buf[d.index(i)] = x
+	return nil
+}
+
+func (d *Deque[T]) Clear() {
+	*d = Deque[T]{}
+}
+
+// Values yields the elements from front to back
+func (d Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.n; i++ {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward yields the elements from back to front
+func (d Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.n - 1; i >= 0; i-- {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package queue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestDequeBothEnds(t *testing.T) {
+	var d Deque[int]
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+
+	if got := slices.Collect(d.Values()); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("Values() = %v, want [0 1 2 3]", got)
+	}
+	if got := slices.Collect(d.Backward()); !slices.Equal(got, []int{3, 2, 1, 0}) {
+		t.Errorf("Backward() = %v, want [3 2 1 0]", got)
+	}
+	if front, _ := d.Front(); front != 0 {
+		t.Errorf("Expected front 0, got %d", front)
+	}
+	if back, _ := d.Back(); back != 3 {
+		t.Errorf("Expected back 3, got %d", back)
+	}
+	if x, _ := d.PopBack(); x != 3 {
+		t.Errorf("Expected PopBack to return 3, got %d", x)
+	}
+	if x, _ := d.PopFront(); x != 0 {
+		t.Errorf("Expected PopFront to return 0, got %d", x)
+	}
+	if d.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", d.Size())
+	}
+}
+
+func TestDequeEmpty(t *testing.T) {
+	d := NewDeque[int]()
+	if _, err := d.PopFront(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on PopFront, got %v", err)
+	}
+	if _, err := d.PopBack(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on PopBack, got %v", err)
+	}
+	if _, err := d.Front(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on Front, got %v", err)
+	}
+	if _, err := d.Back(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on Back, got %v", err)
+	}
+	if _, err := d.At(0); err == nil {
+		t.Error("Expected error on At(0) of an empty deque")
+	}
+}
+
+// Compare against a slice under a random mix of operations, so the ring wraps around
+// and the buffer grows and shrinks many times
+func TestDequeMatchesSlice(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var d Deque[int]
+	var naive []int
+	for i := 0; i < 20000; i++ {
+		// push more than pop in the first half, and the other way around in the second
+		push := r.Intn(10) < 6
+		if i >= 10000 {
+			push = !push
+		}
+		switch {
+		case push && r.Intn(2) == 0:
+			d.PushBack(i)
+			naive = append(naive, i)
+		case push:
+			d.PushFront(i)
+			naive = slices.Insert(naive, 0, i)
+		case r.Intn(2) == 0:
+			got, err := d.PopBack()
+			if len(naive) == 0 {
+				if err == nil {
+					t.Fatal("Expected error on PopBack of an empty deque")
+				}
+				continue
+			}
+			if want := naive[len(naive)-1]; got != want {
+				t.Fatalf("PopBack() = %d, want %d", got, want)
+			}
+			naive = naive[:len(naive)-1]
+		default:
+			got, err := d.PopFront()
+			if len(naive) == 0 {
+				if err == nil {
+					t.Fatal("Expected error on PopFront of an empty deque")
+				}
+				continue
+			}
+			if want := naive[0]; got != want {
+				t.Fatalf("PopFront() = %d, want %d", got, want)
+			}
+			naive = naive[1:]
+		}
+
+		if d.Size() != len(naive) {
+			t.Fatalf("Size() = %d, want %d", d.Size(), len(naive))
+		}
+		if len(naive) > 0 {
+			j := r.Intn(len(naive))
+			if got, _ := d.At(j); got != naive[j] {
+				t.Fatalf("At(%d) = %d, want %d", j, got, naive[j])
+			}
+		}
+	}
+	if got := slices.Collect(d.Values()); !slices.Equal(got, naive) {
+		t.Errorf("Values() differ from the expected elements")
+	}
+}
+
+func TestDequeShrinks(t *testing.T) {
+	d := NewDequeWithCapacity[int](10)
+	if d.Capacity() != 16 {
+		t.Errorf("Expected capacity 16, got %d", d.Capacity())
+	}
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+	}
+	large := d.Capacity()
+	for i := 0; i < 995; i++ {
+		d.PopFront()
+	}
+	if d.Capacity() >= large || d.Capacity() > 4*_minCapacity {
+		t.Errorf("Expected capacity to shrink from %d, got %d", large, d.Capacity())
+	}
+	if got := slices.Collect(d.Values()); !slices.Equal(got, []int{995, 996, 997, 998, 999}) {
+		t.Errorf("Unexpected elements after shrinking: %v", got)
+	}
+}
+
+func TestDequeSet(t *testing.T) {
+	var d Deque[string]
+	d.PushBack("a")
+	d.PushFront("b")
+	if err := d.Set(1, "c"); err != nil {
+		t.Errorf("Unexpected error on Set: %v", err)
+	}
+	if got, _ := d.At(1); got != "c" {
+		t.Errorf("Expected c, got %s", got)
+	}
+	if err := d.Set(2, "d"); err == nil {
+		t.Error("Expected error on Set past the end")
+	}
+}
+
+func BenchmarkDeque(b *testing.B) {
+	var d Deque[int]
+	for i := 0; i < b.N; i++ {
+		d.PushBack(i)
+		if d.Size() > 1000 {
+			d.PopFront()
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package queue
+
+import "sync/atomic"
+
+// LockFree is an unbounded multi-producer multi-consumer queue that never takes a
+// lock. It is the Michael–Scott queue: a linked list with a dummy node at the head,
+// where producers CAS new nodes onto the tail and consumers CAS the head forward.
+// Since the garbage collector never frees a node someone can still see, the classic
+// ABA problem can't happen.
+//
+// Use NewLockFree to create one, the zero value is not ready to use.
+type LockFree[T any] struct {
+	head atomic.Pointer[lockFreeNode[T]]
+	tail atomic.Pointer[lockFreeNode[T]]
+	size atomic.Int64
+}
+
+type lockFreeNode[T any] struct {
+	val  T
+	next atomic.Pointer[lockFreeNode[T]]
+}
+
+func NewLockFree[T any]() *LockFree[T] {
+	q := &LockFree[T]{}
+	dummy := &lockFreeNode[T]{}
+	q.head.Store(dummy)
+	q.tail.Store(dummy)
+	return q
+}
+
+func (q *LockFree[T]) Push(x T) {
+	n := &lockFreeNode[T]{val: x}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// another producer linked its node but hasn't moved the tail yet, help it
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			q.size.Add(1)
+			return
+		}
+	}
+}
+
+func (q *LockFree[T]) Pop() (ret T, err error) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			return ret, ErrEmpty
+		}
+		if head == tail {
+			// the tail is lagging behind a pushed node
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		// read the value before the CAS, once it succeeds another consumer may pop
+		// past next. next becomes the new dummy and keeps its value alive until then,
+		// clearing it here would race with consumers that lost the CAS.
+		ret = next.val
+		if q.head.CompareAndSwap(head, next) {
+			q.size.Add(-1)
+			return ret, nil
+		}
+	}
+}
+
+// Size is exact when the queue is not being modified, and a snapshot otherwise
+func (q *LockFree[T]) Size() int {
+	return int(max(q.size.Load(), 0))
+}
+
+func (q *LockFree[T]) IsEmpty() bool {
+	return q.head.Load().next.Load() == nil
+}
@@ -0,0 +1,147 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLockFreeSequential(t *testing.T) {
+	q := NewLockFree[int]()
+	if _, err := q.Pop(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		q.Push(i)
+	}
+	if q.Size() != 10 || q.IsEmpty() {
+		t.Errorf("Expected size 10, got %d", q.Size())
+	}
+	for i := 0; i < 10; i++ {
+		if x, err := q.Pop(); err != nil || x != i {
+			t.Errorf("Expected %d, got %d, %v", i, x, err)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Error("Expected queue to be empty")
+	}
+}
+
+// Run with -race. Every pushed element must be popped exactly once, and elements of
+// the same producer must come out in the order they were pushed, which is what a
+// linearizable FIFO queue guarantees to each consumer.
+func TestLockFreeConcurrent(t *testing.T) {
+	const (
+		producers = 4
+		consumers = 4
+		perWorker = 20000
+	)
+	q := NewLockFree[[2]int]()
+
+	var producing sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producing.Add(1)
+		go func() {
+			defer producing.Done()
+			for i := 0; i < perWorker; i++ {
+				q.Push([2]int{p, i})
+			}
+		}()
+	}
+
+	popped := make([][][2]int, consumers)
+	finished := make(chan struct{})
+	go func() {
+		producing.Wait()
+		close(finished)
+	}()
+	var consuming sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		consuming.Add(1)
+		go func() {
+			defer consuming.Done()
+			for {
+				x, err := q.Pop()
+				if err == nil {
+					popped[c] = append(popped[c], x)
+					continue
+				}
+				select {
+				case <-finished:
+					// producers are done, so an empty queue stays empty
+					if q.IsEmpty() {
+						return
+					}
+				default:
+				}
+			}
+		}()
+	}
+	consuming.Wait()
+
+	seen := make([][]bool, producers)
+	for p := range seen {
+		seen[p] = make([]bool, perWorker)
+	}
+	for c, xs := range popped {
+		last := make([]int, producers)
+		for p := range last {
+			last[p] = -1
+		}
+		for _, x := range xs {
+			p, i := x[0], x[1]
+			if i <= last[p] {
+				t.Fatalf("Consumer %d popped %d after %d from producer %d", c, i, last[p], p)
+			}
+			if seen[p][i] {
+				t.Fatalf("Element %d of producer %d was popped twice", i, p)
+			}
+			last[p] = i
+			seen[p][i] = true
+		}
+	}
+	for p := range seen {
+		for i, ok := range seen[p] {
+			if !ok {
+				t.Fatalf("Element %d of producer %d was never popped", i, p)
+			}
+		}
+	}
+}
+
+// The benchmarks run pairs of goroutines that push and pop, so they measure the queues
+// under contention
+
+func BenchmarkLockFree(b *testing.B) {
+	q := NewLockFree[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Push(1)
+			q.Pop()
+		}
+	})
+}
+
+func BenchmarkChannel(b *testing.B) {
+	ch := make(chan int, 1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ch <- 1
+			<-ch
+		}
+	})
+}
+
+func BenchmarkMutexQueue(b *testing.B) {
+	var mu sync.Mutex
+	q := New[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			mu.Lock()
+			q.Push(1)
+			mu.Unlock()
+			mu.Lock()
+			q.Pop()
+			mu.Unlock()
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package queue
+
+import (
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+// Monotonic is a FIFO window that answers which of its elements comes first according
+// to C, e.g. the minimum with comparator.Less. Push and Pop take amortised O(1) and
+// Top O(1).
+//
+// It only keeps the elements that can still become the top: an element is dropped as
+// soon as a newer one comes before or ties with it, since the newer one leaves the
+// window later.
+type Monotonic[T any, C comparator.Comparator[T]] struct {
+	d Deque[monotonicEntry[T]]
+	// pushed and popped count the elements that entered and left the window, and
+	// identify each element by its position in the stream
+	pushed, popped int
+	cmp            C
+}
+
+type monotonicEntry[T any] struct {
+	val T
+	seq int
+}
+
+func NewMonotonic[T any, C comparator.Comparator[T]]() Monotonic[T, C] {
+	return Monotonic[T, C]{}
+}
+
+func NewMonotonicWithComparator[T any, C comparator.Comparator[T]](c C) Monotonic[T, C] {
+	return Monotonic[T, C]{cmp: c}
+}
+
+// Size is the number of elements in the window, including the ones that were dropped
+func (m Monotonic[T, C]) Size() int {
+	return m.pushed - m.popped
+}
+
+func (m Monotonic[T, C]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+// Push adds x to the back of the window
+func (m *Monotonic[T, C]) Push(x T) {
+	for {
+		back, err := m.d.Back()
+		if err != nil || m.cmp.Less(back.val, x) {
+			break
+		}
+		m.d.PopBack()
+	}
+	m.d.PushBack(monotonicEntry[T]{x, m.pushed})
+	m.pushed++
+}
+
+// Pop removes the oldest element from the window
+func (m *Monotonic[T, C]) Pop() error {
+	if m.IsEmpty() {
+		return ErrEmpty
+	}
+	if front, _ := m.d.Front(); front.seq == m.popped {
+		m.d.PopFront()
+	}
+	m.popped++
+	return nil
+}
+
+// Top returns the element of the window that comes first according to C
+func (m Monotonic[T, C]) Top() (ret T, err error) {
+	front, err := m.d.Front()
+	return front.val, err
+}
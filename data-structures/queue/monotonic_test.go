@@ -0,0 +1,104 @@
+package queue
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+func TestMonotonicSlidingMinMax(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const k = 7
+	xs := make([]int, 500)
+	for i := range xs {
+		xs[i] = r.Intn(20)
+	}
+
+	mins := NewMonotonic[int, comparator.Less[int]]()
+	maxs := NewMonotonicWithComparator[int](comparator.Greater[int]{})
+	for i, x := range xs {
+		mins.Push(x)
+		maxs.Push(x)
+		if i >= k {
+			mins.Pop()
+			maxs.Pop()
+		}
+		window := xs[max(0, i-k+1) : i+1]
+		if mins.Size() != len(window) {
+			t.Fatalf("Size() = %d, want %d", mins.Size(), len(window))
+		}
+		if got, _ := mins.Top(); got != slices.Min(window) {
+			t.Fatalf("Window %v: min = %d, want %d", window, got, slices.Min(window))
+		}
+		if got, _ := maxs.Top(); got != slices.Max(window) {
+			t.Fatalf("Window %v: max = %d, want %d", window, got, slices.Max(window))
+		}
+	}
+}
+
+func TestMonotonicEmpty(t *testing.T) {
+	m := NewMonotonic[int, comparator.Less[int]]()
+	if _, err := m.Top(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on Top, got %v", err)
+	}
+	if err := m.Pop(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on Pop, got %v", err)
+	}
+	m.Push(1)
+	m.Pop()
+	if !m.IsEmpty() {
+		t.Error("Expected window to be empty")
+	}
+}
+
+func TestSlidingWindowSum(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	w := NewSlidingWindow(0, func(a, b int) int { return a + b })
+	var naive []int
+	for i := 0; i < 2000; i++ {
+		if len(naive) > 0 && r.Intn(3) == 0 {
+			got, _ := w.Pop()
+			if got != naive[0] {
+				t.Fatalf("Pop() = %d, want %d", got, naive[0])
+			}
+			naive = naive[1:]
+		} else {
+			x := r.Intn(100)
+			w.Push(x)
+			naive = append(naive, x)
+		}
+		sum := 0
+		for _, x := range naive {
+			sum += x
+		}
+		if got := w.Query(); got != sum {
+			t.Fatalf("Query() = %d, want %d", got, sum)
+		}
+	}
+}
+
+// Concatenation isn't commutative, so this checks the elements are combined in order
+func TestSlidingWindowOrder(t *testing.T) {
+	w := NewSlidingWindow("", func(a, b string) string { return a + b })
+	if w.Query() != "" {
+		t.Errorf("Expected the identity on an empty window, got %q", w.Query())
+	}
+	if _, err := w.Pop(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty, got %v", err)
+	}
+
+	letters := strings.Split("abcdefghij", "")
+	for i, l := range letters {
+		w.Push(l)
+		if i >= 3 {
+			w.Pop()
+		}
+		want := strings.Join(letters[max(0, i-2):i+1], "")
+		if got := w.Query(); got != want {
+			t.Errorf("Query() = %q, want %q", got, want)
+		}
+	}
+}
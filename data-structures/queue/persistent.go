@@ -0,0 +1,142 @@
+package queue
+
+import (
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/lucasturci/everything-go/data-structures/stack"
+)
+
+// Persistent is an immutable FIFO queue. Push and Pop return new versions in O(1)
+// worst case and leave the version they were called on untouched, so old versions stay
+// valid and share structure with the new ones. The zero value is an empty queue ready
+// to use.
+//
+// It is Okasaki's real-time queue: the front is a lazy list and the back a stack. When
+// the back grows longer than the front, the back is reversed onto the front, but
+// lazily, one element per later operation, driven by the schedule s. That's what keeps
+// every operation O(1) even when the same version is used many times, which would break
+// the amortised bound of a plain two-stack queue.
+type Persistent[T any] struct {
+	f *stream[T]
+	r stack.Persistent[T]
+	// s is the part of f that hasn't been evaluated yet
+	s *stream[T]
+	n int
+}
+
+// stream is a lazy list. A nil stream is empty, and a stream's cell is computed at
+// most once, so versions sharing it share the work too. Computing it is safe for
+// concurrent use, as different goroutines may hold versions sharing the same stream.
+type stream[T any] struct {
+	once  sync.Once
+	thunk func() *cell[T]
+	cell  *cell[T] // nil when the stream is empty
+}
+
+type cell[T any] struct {
+	head T
+	tail *stream[T]
+}
+
+func lazy[T any](thunk func() *cell[T]) *stream[T] {
+	return &stream[T]{thunk: thunk}
+}
+
+func evaluated[T any](c *cell[T]) *stream[T] {
+	s := &stream[T]{cell: c}
+	s.once.Do(func() {})
+	return s
+}
+
+func (s *stream[T]) force() *cell[T] {
+	if s == nil {
+		return nil
+	}
+	s.once.Do(func() {
+		s.cell = s.thunk()
+		s.thunk = nil
+	})
+	return s.cell
+}
+
+// rotate returns f ++ reverse(r) ++ a, where r has one element more than f. Each cell
+// takes O(1) to force.
+func rotate[T any](f *stream[T], r stack.Persistent[T], a *stream[T]) *stream[T] {
+	return lazy(func() *cell[T] {
+		head, rest, _ := r.Pop()
+		c := f.force()
+		if c == nil {
+			return &cell[T]{head, a}
+		}
+		return &cell[T]{c.head, rotate(c.tail, rest, evaluated(&cell[T]{head, a}))}
+	})
+}
+
+func NewPersistent[T any]() Persistent[T] {
+	return Persistent[T]{}
+}
+
+func (q Persistent[T]) Size() int {
+	return q.n
+}
+
+func (q Persistent[T]) IsEmpty() bool {
+	return q.n == 0
+}
+
+// exec evaluates one more cell of the front, or starts a new rotation once all of it
+// is evaluated, which happens exactly when the back is one longer than the front
+func (q Persistent[T]) exec() Persistent[T] {
+	if c := q.s.force(); c != nil {
+		q.s = c.tail
+		return q
+	}
+	q.f = rotate(q.f, q.r, nil)
+	q.r = stack.NewPersistent[T]()
+	q.s = q.f
+	return q
+}
+
+func (q Persistent[T]) Push(x T) Persistent[T] {
+	q.r = q.r.Push(x)
+	q.n++
+	return q.exec()
+}
+
+// Pop returns the front element and the queue without it
+func (q Persistent[T]) Pop() (ret T, rest Persistent[T], err error) {
+	c := q.f.force()
+	if c == nil {
+		return ret, q, ErrEmpty
+	}
+	q.f = c.tail
+	q.n--
+	return c.head, q.exec(), nil
+}
+
+func (q Persistent[T]) Front() (ret T, err error) {
+	c := q.f.force()
+	if c == nil {
+		return ret, ErrEmpty
+	}
+	return c.head, nil
+}
+
+// Values yields the elements in the order they will be popped
+func (q Persistent[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for c := q.f.force(); c != nil; c = c.tail.force() {
+			if !yield(c.head) {
+				return
+			}
+		}
+		back := slices.Collect(q.r.Values())
+		for i := len(back) - 1; i >= 0; i-- {
+			if !yield(back[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package queue
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestPersistentVersions(t *testing.T) {
+	empty := NewPersistent[int]()
+	q := empty.Push(1).Push(2).Push(3)
+	a := q.Push(4)
+	b := q.Push(5)
+
+	x, popped, err := a.Pop()
+	if err != nil || x != 1 {
+		t.Errorf("Expected to pop 1, got %d, %v", x, err)
+	}
+	if !empty.IsEmpty() {
+		t.Error("Pushing should not modify the empty queue")
+	}
+
+	tests := []struct {
+		name     string
+		q        Persistent[int]
+		expected []int
+	}{
+		{"original", q, []int{1, 2, 3}},
+		{"a", a, []int{1, 2, 3, 4}},
+		{"b", b, []int{1, 2, 3, 5}},
+		{"popped", popped, []int{2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(tt.q.Values()); !slices.Equal(got, tt.expected) {
+				t.Errorf("Values() = %v, want %v", got, tt.expected)
+			}
+			if tt.q.Size() != len(tt.expected) {
+				t.Errorf("Size() = %d, want %d", tt.q.Size(), len(tt.expected))
+			}
+			if front, _ := tt.q.Front(); front != tt.expected[0] {
+				t.Errorf("Front() = %d, want %d", front, tt.expected[0])
+			}
+		})
+	}
+}
+
+func TestPersistentEmpty(t *testing.T) {
+	var q Persistent[int]
+	if _, _, err := q.Pop(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on Pop, got %v", err)
+	}
+	if _, err := q.Front(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on Front, got %v", err)
+	}
+}
+
+// Keep many versions around and randomly extend any of them, checking each against a
+// slice copy
+func TestPersistentRandomVersions(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	versions := []Persistent[int]{NewPersistent[int]()}
+	naive := [][]int{nil}
+	for i := 0; i < 3000; i++ {
+		j := r.Intn(len(versions))
+		q, xs := versions[j], naive[j]
+		if len(xs) > 0 && r.Intn(3) == 0 {
+			x, rest, err := q.Pop()
+			if err != nil || x != xs[0] {
+				t.Fatalf("Pop() = %d, %v, want %d", x, err, xs[0])
+			}
+			q, xs = rest, xs[1:]
+		} else {
+			q, xs = q.Push(i), append(slices.Clip(xs), i)
+		}
+		versions = append(versions, q)
+		naive = append(naive, xs)
+	}
+	for j, q := range versions {
+		if got := slices.Collect(q.Values()); !slices.Equal(got, naive[j]) {
+			t.Fatalf("Version %d: Values() = %v, want %v", j, got, naive[j])
+		}
+	}
+}
+
+// Run with -race. Versions sharing lazy cells can be used from several goroutines.
+func TestPersistentConcurrentReaders(t *testing.T) {
+	q := NewPersistent[int]()
+	for i := 0; i < 100; i++ {
+		q = q.Push(i)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := q
+			for i := 0; i < 100; i++ {
+				x, rest, _ := p.Pop()
+				if x != i {
+					t.Errorf("Expected %d, got %d", i, x)
+					return
+				}
+				p = rest
+			}
+		}()
+	}
+	wg.Wait()
+}
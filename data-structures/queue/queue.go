@@ -1,58 +1,37 @@
 package queue
 
-import (
-	"errors"
-
-	"github.com/lucasturci/everything-go/data-structures/stack"
-)
-
-// Write queue with two stacks.
+import "iter"
 
+// Queue is a FIFO queue backed by a Deque
 type Queue[T any] struct {
-	r stack.Stack[T]
-	l stack.Stack[T]
+	d Deque[T]
 }
 
 func New[T any]() Queue[T] {
-	return Queue[T]{
-		r: stack.New[T](),
-		l: stack.New[T](),
-	}
+	return Queue[T]{}
 }
 
 func (q *Queue[T]) Push(x T) {
-	q.r.Push(x)
+	q.d.PushBack(x)
 }
 
 func (q Queue[T]) IsEmpty() bool {
-	return q.l.IsEmpty() && q.r.IsEmpty()
+	return q.d.IsEmpty()
 }
 
 func (q Queue[T]) Size() int {
-	return q.l.Size() + q.r.Size()
-}
-
-func (q *Queue[T]) flush() {
-	q.l = stack.Reverse(q.r)
-	q.r = stack.New[T]()
+	return q.d.Size()
 }
 
 func (q *Queue[T]) Pop() (ret T, err error) {
-	if q.IsEmpty() {
-		return ret, errors.New("queue is empty")
-	}
-	if q.l.IsEmpty() {
-		q.flush()
-	}
-	return q.l.Pop()
+	return q.d.PopFront()
 }
 
 func (q Queue[T]) Front() (ret T, err error) {
-	if q.IsEmpty() {
-		return ret, errors.New("queue is empty")
-	}
-	if q.l.IsEmpty() {
-		q.flush()
-	}
-	return q.l.Top()
+	return q.d.Front()
+}
+
+// Values yields the elements in the order they will be popped
+func (q Queue[T]) Values() iter.Seq[T] {
+	return q.d.Values()
 }
@@ -100,3 +100,17 @@ func TestMultipleOperations(t *testing.T) {
 		t.Error("Queue should be empty after popping all elements")
 	}
 }
+
+func TestFrontThenPop(t *testing.T) {
+	q := New[int]()
+	for i := 0; i < 5; i++ {
+		q.Push(i)
+	}
+	for i := 0; i < 5; i++ {
+		front, _ := q.Front()
+		val, _ := q.Pop()
+		if front != i || val != i {
+			t.Errorf("Expected Front and Pop to return %d, got %d and %d", i, front, val)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"github.com/lucasturci/everything-go/data-structures/stack"
+)
+
+// SlidingWindow is a FIFO queue that can combine all of its elements in amortised O(1),
+// for any associative combine function with an identity element (a monoid): sums,
+// minimums, products of matrices, ...
+//
+// It is the two-stack queue: elements are pushed to the back stack and popped from the
+// front stack, and each stack entry also holds the combination of itself and all the
+// entries below it. combine doesn't need to be commutative, Query always combines
+// the elements from oldest to newest.
+type SlidingWindow[T any] struct {
+	front, back stack.Stack[windowEntry[T]]
+	identity    T
+	combine     func(a, b T) T
+}
+
+type windowEntry[T any] struct {
+	val T
+	agg T
+}
+
+func NewSlidingWindow[T any](identity T, combine func(a, b T) T) SlidingWindow[T] {
+	return SlidingWindow[T]{
+		front:    stack.New[windowEntry[T]](),
+		back:     stack.New[windowEntry[T]](),
+		identity: identity,
+		combine:  combine,
+	}
+}
+
+func (w SlidingWindow[T]) Size() int {
+	return w.front.Size() + w.back.Size()
+}
+
+func (w SlidingWindow[T]) IsEmpty() bool {
+	return w.Size() == 0
+}
+
+func agg[T any](s stack.Stack[windowEntry[T]], identity T) T {
+	if top, err := s.Top(); err == nil {
+		return top.agg
+	}
+	return identity
+}
+
+// Push adds x to the back of the window
+func (w *SlidingWindow[T]) Push(x T) {
+	w.back.Push(windowEntry[T]{x, w.combine(agg(w.back, w.identity), x)})
+}
+
+// Pop removes the oldest element from the window and returns it
+func (w *SlidingWindow[T]) Pop() (ret T, err error) {
+	if w.IsEmpty() {
+		return ret, ErrEmpty
+	}
+	if w.front.IsEmpty() {
+		// move everything to the front stack, where the oldest element ends up on top
+		for !w.back.IsEmpty() {
+			e, _ := w.back.Pop()
+			w.front.Push(windowEntry[T]{e.val, w.combine(e.val, agg(w.front, w.identity))})
+		}
+	}
+	e, err := w.front.Pop()
+	return e.val, err
+}
+
+// Query combines the elements of the window from oldest to newest. It returns the
+// identity when the window is empty.
+func (w SlidingWindow[T]) Query() T {
+	return w.combine(agg(w.front, w.identity), agg(w.back, w.identity))
+}
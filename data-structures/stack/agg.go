@@ -0,0 +1,115 @@
+package stack
+
+import (
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+// AggStack is a stack that also keeps the combination of all its elements, for any
+// associative combine function, so Query takes O(1). Each entry stores the aggregate
+// of itself and everything below it, so popping needs no recomputation.
+type AggStack[T any] struct {
+	s       Stack[aggEntry[T]]
+	combine func(a, b T) T
+}
+
+type aggEntry[T any] struct {
+	val T
+	agg T
+}
+
+// NewAggStack creates a stack whose Query is combine applied from the bottom to the
+// top, i.e. combine(combine(x0, x1), x2) and so on
+func NewAggStack[T any](combine func(a, b T) T) AggStack[T] {
+	return AggStack[T]{s: New[aggEntry[T]](), combine: combine}
+}
+
+func (s AggStack[T]) Size() int {
+	return s.s.Size()
+}
+
+func (s AggStack[T]) IsEmpty() bool {
+	return s.s.IsEmpty()
+}
+
+func (s *AggStack[T]) Push(element T) {
+	agg := element
+	if top, err := s.s.Top(); err == nil {
+		agg = s.combine(top.agg, element)
+	}
+	s.s.Push(aggEntry[T]{element, agg})
+}
+
+func (s *AggStack[T]) Pop() (ret T, err error) {
+	e, err := s.s.Pop()
+	return e.val, err
+}
+
+func (s AggStack[T]) Top() (ret T, err error) {
+	e, err := s.s.Top()
+	return e.val, err
+}
+
+// Query returns the aggregate of all elements in the stack
+func (s AggStack[T]) Query() (ret T, err error) {
+	e, err := s.s.Top()
+	return e.agg, err
+}
+
+// MinStack is a stack that knows its minimum and maximum according to C
+type MinStack[T any, C comparator.Comparator[T]] struct {
+	s   Stack[minEntry[T]]
+	cmp C
+}
+
+type minEntry[T any] struct {
+	val, min, max T
+}
+
+func NewMinStack[T any, C comparator.Comparator[T]]() MinStack[T, C] {
+	return MinStack[T, C]{s: New[minEntry[T]]()}
+}
+
+func NewMinStackWithComparator[T any, C comparator.Comparator[T]](c C) MinStack[T, C] {
+	return MinStack[T, C]{s: New[minEntry[T]](), cmp: c}
+}
+
+func (s MinStack[T, C]) Size() int {
+	return s.s.Size()
+}
+
+func (s MinStack[T, C]) IsEmpty() bool {
+	return s.s.IsEmpty()
+}
+
+func (s *MinStack[T, C]) Push(element T) {
+	e := minEntry[T]{element, element, element}
+	if top, err := s.s.Top(); err == nil {
+		if s.cmp.Less(top.min, element) {
+			e.min = top.min
+		}
+		if s.cmp.Less(element, top.max) {
+			e.max = top.max
+		}
+	}
+	s.s.Push(e)
+}
+
+func (s *MinStack[T, C]) Pop() (ret T, err error) {
+	e, err := s.s.Pop()
+	return e.val, err
+}
+
+func (s MinStack[T, C]) Top() (ret T, err error) {
+	e, err := s.s.Top()
+	return e.val, err
+}
+
+func (s MinStack[T, C]) Min() (ret T, err error) {
+	e, err := s.s.Top()
+	return e.min, err
+}
+
+func (s MinStack[T, C]) Max() (ret T, err error) {
+	e, err := s.s.Top()
+	return e.max, err
+}
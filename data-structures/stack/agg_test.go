@@ -0,0 +1,70 @@
+package stack
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+func TestMinStack(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := NewMinStack[int, comparator.Less[int]]()
+	var naive []int
+	for i := 0; i < 2000; i++ {
+		if len(naive) > 0 && r.Intn(3) == 0 {
+			got, _ := s.Pop()
+			if want := naive[len(naive)-1]; got != want {
+				t.Fatalf("Pop() = %d, want %d", got, want)
+			}
+			naive = naive[:len(naive)-1]
+		} else {
+			x := r.Intn(1000)
+			s.Push(x)
+			naive = append(naive, x)
+		}
+		if len(naive) == 0 {
+			continue
+		}
+		if got, _ := s.Min(); got != slices.Min(naive) {
+			t.Fatalf("Min() = %d, want %d", got, slices.Min(naive))
+		}
+		if got, _ := s.Max(); got != slices.Max(naive) {
+			t.Fatalf("Max() = %d, want %d", got, slices.Max(naive))
+		}
+	}
+}
+
+func TestMinStackEmpty(t *testing.T) {
+	s := NewMinStackWithComparator[int](comparator.Greater[int]{})
+	if _, err := s.Min(); err == nil {
+		t.Error("Expected error on Min of empty stack")
+	}
+	s.Push(1)
+	s.Push(3)
+	// with Greater, the "minimum" is the largest element
+	if got, _ := s.Min(); got != 3 {
+		t.Errorf("Expected 3, got %d", got)
+	}
+}
+
+func TestAggStack(t *testing.T) {
+	s := NewAggStack(func(a, b string) string { return a + b })
+	if _, err := s.Query(); err == nil {
+		t.Error("Expected error on Query of empty stack")
+	}
+	s.Push("a")
+	s.Push("b")
+	s.Push("c")
+	if got, _ := s.Query(); got != "abc" {
+		t.Errorf("Expected abc, got %s", got)
+	}
+	s.Pop()
+	if got, _ := s.Query(); got != "ab" {
+		t.Errorf("Expected ab after Pop, got %s", got)
+	}
+	if top, _ := s.Top(); top != "b" || s.Size() != 2 {
+		t.Errorf("Expected top b and size 2, got %s and %d", top, s.Size())
+	}
+}
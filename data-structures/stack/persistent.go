@@ -0,0 +1,74 @@
+package stack
+
+import (
+	"errors"
+	"iter"
+)
+
+// Persistent is an immutable stack. Push and Pop return new versions in O(1) and leave
+// the version they were called on untouched, with every version sharing the nodes
+// they have in common. The zero value is an empty stack ready to use.
+type Persistent[T any] struct {
+	top *consNode[T]
+}
+
+type consNode[T any] struct {
+	val  T
+	next *consNode[T]
+	size int
+}
+
+func NewPersistent[T any]() Persistent[T] {
+	return Persistent[T]{}
+}
+
+func (s Persistent[T]) Size() int {
+	if s.top == nil {
+		return 0
+	}
+	return s.top.size
+}
+
+func (s Persistent[T]) IsEmpty() bool {
+	return s.top == nil
+}
+
+func (s Persistent[T]) Push(element T) Persistent[T] {
+	return Persistent[T]{&consNode[T]{element, s.top, s.Size() + 1}}
+}
+
+// Pop returns the top element and the stack without it
+func (s Persistent[T]) Pop() (ret T, rest Persistent[T], err error) {
+	if s.IsEmpty() {
+		return ret, s, errors.New("stack is empty")
+	}
+	return s.top.val, Persistent[T]{s.top.next}, nil
+}
+
+func (s Persistent[T]) Top() (ret T, err error) {
+	if s.IsEmpty() {
+		return ret, errors.New("stack is empty")
+	}
+	return s.top.val, nil
+}
+
+// Values yields the elements from the top to the bottom
+func (s Persistent[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := s.top; n != nil; n = n.next {
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
+
+// ReversePersistent returns a new stack with the elements of s in reverse order. It
+// takes O(n), as nothing can be shared with s.
+func ReversePersistent[T any](s Persistent[T]) Persistent[T] {
+	ret := NewPersistent[T]()
+	for x := range s.Values() {
+		ret = ret.Push(x)
+	}
+	return ret
+}
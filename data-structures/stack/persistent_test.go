@@ -0,0 +1,58 @@
+package stack
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPersistentVersions(t *testing.T) {
+	empty := NewPersistent[int]()
+	one := empty.Push(1)
+	two := one.Push(2)
+	other := one.Push(3)
+
+	if !empty.IsEmpty() || empty.Size() != 0 {
+		t.Error("Pushing should not modify the empty stack")
+	}
+	if got := slices.Collect(two.Values()); !slices.Equal(got, []int{2, 1}) {
+		t.Errorf("Expected [2 1], got %v", got)
+	}
+	if got := slices.Collect(other.Values()); !slices.Equal(got, []int{3, 1}) {
+		t.Errorf("Expected [3 1], got %v", got)
+	}
+	if two.top.next != other.top.next {
+		t.Error("Expected versions to share their common nodes")
+	}
+
+	val, rest, err := two.Pop()
+	if err != nil || val != 2 {
+		t.Errorf("Expected to pop 2, got %d, %v", val, err)
+	}
+	if rest.Size() != 1 || two.Size() != 2 {
+		t.Errorf("Expected sizes 1 and 2, got %d and %d", rest.Size(), two.Size())
+	}
+	if top, _ := rest.Top(); top != 1 {
+		t.Errorf("Expected top 1, got %d", top)
+	}
+}
+
+func TestPersistentEmpty(t *testing.T) {
+	var s Persistent[int]
+	if _, _, err := s.Pop(); err == nil {
+		t.Error("Expected error when popping from empty stack")
+	}
+	if _, err := s.Top(); err == nil {
+		t.Error("Expected error on Top of empty stack")
+	}
+}
+
+func TestReversePersistent(t *testing.T) {
+	s := NewPersistent[int]().Push(1).Push(2).Push(3)
+	r := ReversePersistent(s)
+	if got := slices.Collect(r.Values()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+	if got := slices.Collect(s.Values()); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("Reverse should not modify the original, got %v", got)
+	}
+}
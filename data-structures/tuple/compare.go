@@ -0,0 +1,115 @@
+package tuple
+
+import (
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+// Lexicographic comparators: tuples are ordered by their first fields, then ties are
+// broken by the second fields, and so on. The zero values are ready to use when the
+// field comparators are, e.g. PairComparator[int, string, comparator.Less[int],
+// comparator.Less[string]]{}. Field comparators that are also three-way comparators
+// are compared in a single call.
+
+type PairComparator[T1 any, T2 any, C1 comparator.Comparator[T1], C2 comparator.Comparator[T2]] struct {
+	c1 C1
+	c2 C2
+}
+
+func NewPairComparator[T1 any, T2 any, C1 comparator.Comparator[T1], C2 comparator.Comparator[T2]](c1 C1, c2 C2) PairComparator[T1, T2, C1, C2] {
+	return PairComparator[T1, T2, C1, C2]{c1, c2}
+}
+
+func (pc PairComparator[T1, T2, C1, C2]) Less(a, b Pair[T1, T2]) bool {
+	return pc.Compare(a, b) < 0
+}
+
+func (pc PairComparator[T1, T2, C1, C2]) Compare(a, b Pair[T1, T2]) int {
+	if r := comparator.Compare(pc.c1, a.First, b.First); r != 0 {
+		return r
+	}
+	return comparator.Compare(pc.c2, a.Second, b.Second)
+}
+
+type TripleComparator[T1 any, T2 any, T3 any, C1 comparator.Comparator[T1], C2 comparator.Comparator[T2], C3 comparator.Comparator[T3]] struct {
+	c1 C1
+	c2 C2
+	c3 C3
+}
+
+func NewTripleComparator[T1 any, T2 any, T3 any, C1 comparator.Comparator[T1], C2 comparator.Comparator[T2], C3 comparator.Comparator[T3]](c1 C1, c2 C2, c3 C3) TripleComparator[T1, T2, T3, C1, C2, C3] {
+	return TripleComparator[T1, T2, T3, C1, C2, C3]{c1, c2, c3}
+}
+
+func (tc TripleComparator[T1, T2, T3, C1, C2, C3]) Less(a, b Triple[T1, T2, T3]) bool {
+	return tc.Compare(a, b) < 0
+}
+
+func (tc TripleComparator[T1, T2, T3, C1, C2, C3]) Compare(a, b Triple[T1, T2, T3]) int {
+	if r := comparator.Compare(tc.c1, a.First, b.First); r != 0 {
+		return r
+	}
+	if r := comparator.Compare(tc.c2, a.Second, b.Second); r != 0 {
+		return r
+	}
+	return comparator.Compare(tc.c3, a.Third, b.Third)
+}
+
+type Tuple4Comparator[T1 any, T2 any, T3 any, T4 any, C1 comparator.Comparator[T1], C2 comparator.Comparator[T2], C3 comparator.Comparator[T3], C4 comparator.Comparator[T4]] struct {
+	c1 C1
+	c2 C2
+	c3 C3
+	c4 C4
+}
+
+func NewTuple4Comparator[T1 any, T2 any, T3 any, T4 any, C1 comparator.Comparator[T1], C2 comparator.Comparator[T2], C3 comparator.Comparator[T3], C4 comparator.Comparator[T4]](c1 C1, c2 C2, c3 C3, c4 C4) Tuple4Comparator[T1, T2, T3, T4, C1, C2, C3, C4] {
+	return Tuple4Comparator[T1, T2, T3, T4, C1, C2, C3, C4]{c1, c2, c3, c4}
+}
+
+func (tc Tuple4Comparator[T1, T2, T3, T4, C1, C2, C3, C4]) Less(a, b Tuple4[T1, T2, T3, T4]) bool {
+	return tc.Compare(a, b) < 0
+}
+
+func (tc Tuple4Comparator[T1, T2, T3, T4, C1, C2, C3, C4]) Compare(a, b Tuple4[T1, T2, T3, T4]) int {
+	if r := comparator.Compare(tc.c1, a.First, b.First); r != 0 {
+		return r
+	}
+	if r := comparator.Compare(tc.c2, a.Second, b.Second); r != 0 {
+		return r
+	}
+	if r := comparator.Compare(tc.c3, a.Third, b.Third); r != 0 {
+		return r
+	}
+	return comparator.Compare(tc.c4, a.Fourth, b.Fourth)
+}
+
+type Tuple5Comparator[T1 any, T2 any, T3 any, T4 any, T5 any, C1 comparator.Comparator[T1], C2 comparator.Comparator[T2], C3 comparator.Comparator[T3], C4 comparator.Comparator[T4], C5 comparator.Comparator[T5]] struct {
+	c1 C1
+	c2 C2
+	c3 C3
+	c4 C4
+	c5 C5
+}
+
+func NewTuple5Comparator[T1 any, T2 any, T3 any, T4 any, T5 any, C1 comparator.Comparator[T1], C2 comparator.Comparator[T2], C3 comparator.Comparator[T3], C4 comparator.Comparator[T4], C5 comparator.Comparator[T5]](c1 C1, c2 C2, c3 C3, c4 C4, c5 C5) Tuple5Comparator[T1, T2, T3, T4, T5, C1, C2, C3, C4, C5] {
+	return Tuple5Comparator[T1, T2, T3, T4, T5, C1, C2, C3, C4, C5]{c1, c2, c3, c4, c5}
+}
+
+func (tc Tuple5Comparator[T1, T2, T3, T4, T5, C1, C2, C3, C4, C5]) Less(a, b Tuple5[T1, T2, T3, T4, T5]) bool {
+	return tc.Compare(a, b) < 0
+}
+
+func (tc Tuple5Comparator[T1, T2, T3, T4, T5, C1, C2, C3, C4, C5]) Compare(a, b Tuple5[T1, T2, T3, T4, T5]) int {
+	if r := comparator.Compare(tc.c1, a.First, b.First); r != 0 {
+		return r
+	}
+	if r := comparator.Compare(tc.c2, a.Second, b.Second); r != 0 {
+		return r
+	}
+	if r := comparator.Compare(tc.c3, a.Third, b.Third); r != 0 {
+		return r
+	}
+	if r := comparator.Compare(tc.c4, a.Fourth, b.Fourth); r != 0 {
+		return r
+	}
+	return comparator.Compare(tc.c5, a.Fifth, b.Fifth)
+}
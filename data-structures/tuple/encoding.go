@@ -0,0 +1,62 @@
+package tuple
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Tuples are encoded in JSON as arrays, so Pair{1, "a"} becomes [1,"a"]
+
+// unmarshalArray decodes a JSON array with exactly len(fields) elements into fields.
+// Like encoding/json does, a null leaves the fields untouched.
+func unmarshalArray(data []byte, fields ...any) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != len(fields) {
+		return fmt.Errorf("expected a JSON array of %v elements, got %v", len(fields), len(raw))
+	}
+	for i, f := range fields {
+		if err := json.Unmarshal(raw[i], f); err != nil {
+			return fmt.Errorf("element %v of tuple: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p Pair[T1, T2]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{p.First, p.Second})
+}
+
+func (p *Pair[T1, T2]) UnmarshalJSON(data []byte) error {
+	return unmarshalArray(data, &p.First, &p.Second)
+}
+
+func (t Triple[T1, T2, T3]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.First, t.Second, t.Third})
+}
+
+func (t *Triple[T1, T2, T3]) UnmarshalJSON(data []byte) error {
+	return unmarshalArray(data, &t.First, &t.Second, &t.Third)
+}
+
+func (t Tuple4[T1, T2, T3, T4]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.First, t.Second, t.Third, t.Fourth})
+}
+
+func (t *Tuple4[T1, T2, T3, T4]) UnmarshalJSON(data []byte) error {
+	return unmarshalArray(data, &t.First, &t.Second, &t.Third, &t.Fourth)
+}
+
+func (t Tuple5[T1, T2, T3, T4, T5]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.First, t.Second, t.Third, t.Fourth, t.Fifth})
+}
+
+func (t *Tuple5[T1, T2, T3, T4, T5]) UnmarshalJSON(data []byte) error {
+	return unmarshalArray(data, &t.First, &t.Second, &t.Third, &t.Fourth, &t.Fifth)
+}
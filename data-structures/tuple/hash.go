@@ -0,0 +1,132 @@
+package tuple
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+)
+
+// Hasher feeds values of type T to a maphash.Hash. Values that are equal must write
+// the same bytes, and the bytes must not be a prefix of another value's, so that the
+// fields of a tuple can't run into each other.
+type Hasher[T any] interface {
+	WriteHash(h *maphash.Hash, v T)
+}
+
+// Hash returns the hash of v with the given seed
+func Hash[T any, H Hasher[T]](seed maphash.Seed, hasher H, v T) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	hasher.WriteHash(&h, v)
+	return h.Sum64()
+}
+
+// Predefined field hashers. Their zero values are ready to use.
+
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type IntHasher[T integer] struct{}
+
+func (IntHasher[T]) WriteHash(h *maphash.Hash, v T) {
+	h.Write(binary.LittleEndian.AppendUint64(nil, uint64(v)))
+}
+
+// FloatHasher hashes 0 and -0 alike, as they are equal
+type FloatHasher[T ~float32 | ~float64] struct{}
+
+func (FloatHasher[T]) WriteHash(h *maphash.Hash, v T) {
+	f := float64(v)
+	if f == 0 {
+		f = 0
+	}
+	h.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(f)))
+}
+
+type StringHasher[T ~string] struct{}
+
+func (StringHasher[T]) WriteHash(h *maphash.Hash, v T) {
+	h.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(v))))
+	h.WriteString(string(v))
+}
+
+type BoolHasher struct{}
+
+func (BoolHasher) WriteHash(h *maphash.Hash, v bool) {
+	if v {
+		h.WriteByte(1)
+	} else {
+		h.WriteByte(0)
+	}
+}
+
+// Tuple hashers write their fields in order, so they can be nested in each other
+
+type PairHasher[T1 any, T2 any, H1 Hasher[T1], H2 Hasher[T2]] struct {
+	h1 H1
+	h2 H2
+}
+
+func NewPairHasher[T1 any, T2 any, H1 Hasher[T1], H2 Hasher[T2]](h1 H1, h2 H2) PairHasher[T1, T2, H1, H2] {
+	return PairHasher[T1, T2, H1, H2]{h1, h2}
+}
+
+func (ph PairHasher[T1, T2, H1, H2]) WriteHash(h *maphash.Hash, p Pair[T1, T2]) {
+	ph.h1.WriteHash(h, p.First)
+	ph.h2.WriteHash(h, p.Second)
+}
+
+type TripleHasher[T1 any, T2 any, T3 any, H1 Hasher[T1], H2 Hasher[T2], H3 Hasher[T3]] struct {
+	h1 H1
+	h2 H2
+	h3 H3
+}
+
+func NewTripleHasher[T1 any, T2 any, T3 any, H1 Hasher[T1], H2 Hasher[T2], H3 Hasher[T3]](h1 H1, h2 H2, h3 H3) TripleHasher[T1, T2, T3, H1, H2, H3] {
+	return TripleHasher[T1, T2, T3, H1, H2, H3]{h1, h2, h3}
+}
+
+func (th TripleHasher[T1, T2, T3, H1, H2, H3]) WriteHash(h *maphash.Hash, t Triple[T1, T2, T3]) {
+	th.h1.WriteHash(h, t.First)
+	th.h2.WriteHash(h, t.Second)
+	th.h3.WriteHash(h, t.Third)
+}
+
+type Tuple4Hasher[T1 any, T2 any, T3 any, T4 any, H1 Hasher[T1], H2 Hasher[T2], H3 Hasher[T3], H4 Hasher[T4]] struct {
+	h1 H1
+	h2 H2
+	h3 H3
+	h4 H4
+}
+
+func NewTuple4Hasher[T1 any, T2 any, T3 any, T4 any, H1 Hasher[T1], H2 Hasher[T2], H3 Hasher[T3], H4 Hasher[T4]](h1 H1, h2 H2, h3 H3, h4 H4) Tuple4Hasher[T1, T2, T3, T4, H1, H2, H3, H4] {
+	return Tuple4Hasher[T1, T2, T3, T4, H1, H2, H3, H4]{h1, h2, h3, h4}
+}
+
+func (th Tuple4Hasher[T1, T2, T3, T4, H1, H2, H3, H4]) WriteHash(h *maphash.Hash, t Tuple4[T1, T2, T3, T4]) {
+	th.h1.WriteHash(h, t.First)
+	th.h2.WriteHash(h, t.Second)
+	th.h3.WriteHash(h, t.Third)
+	th.h4.WriteHash(h, t.Fourth)
+}
+
+type Tuple5Hasher[T1 any, T2 any, T3 any, T4 any, T5 any, H1 Hasher[T1], H2 Hasher[T2], H3 Hasher[T3], H4 Hasher[T4], H5 Hasher[T5]] struct {
+	h1 H1
+	h2 H2
+	h3 H3
+	h4 H4
+	h5 H5
+}
+
+func NewTuple5Hasher[T1 any, T2 any, T3 any, T4 any, T5 any, H1 Hasher[T1], H2 Hasher[T2], H3 Hasher[T3], H4 Hasher[T4], H5 Hasher[T5]](h1 H1, h2 H2, h3 H3, h4 H4, h5 H5) Tuple5Hasher[T1, T2, T3, T4, T5, H1, H2, H3, H4, H5] {
+	return Tuple5Hasher[T1, T2, T3, T4, T5, H1, H2, H3, H4, H5]{h1, h2, h3, h4, h5}
+}
+
+func (th Tuple5Hasher[T1, T2, T3, T4, T5, H1, H2, H3, H4, H5]) WriteHash(h *maphash.Hash, t Tuple5[T1, T2, T3, T4, T5]) {
+	th.h1.WriteHash(h, t.First)
+	th.h2.WriteHash(h, t.Second)
+	th.h3.WriteHash(h, t.Third)
+	th.h4.WriteHash(h, t.Fourth)
+	th.h5.WriteHash(h, t.Fifth)
+}
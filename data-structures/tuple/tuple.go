@@ -1,5 +1,7 @@
 package tuple
 
+import "fmt"
+
 // Pair type
 
 type Pair[T1 any, T2 any] struct {
@@ -7,6 +9,18 @@ type Pair[T1 any, T2 any] struct {
 	Second T2
 }
 
+func MakePair[T1 any, T2 any](first T1, second T2) Pair[T1, T2] {
+	return Pair[T1, T2]{first, second}
+}
+
+func (p Pair[T1, T2]) Unpack() (T1, T2) {
+	return p.First, p.Second
+}
+
+func (p Pair[T1, T2]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
 // Triple type
 
 type Triple[T1 any, T2 any, T3 any] struct {
@@ -15,6 +29,18 @@ type Triple[T1 any, T2 any, T3 any] struct {
 	Third  T3
 }
 
+func MakeTriple[T1 any, T2 any, T3 any](first T1, second T2, third T3) Triple[T1, T2, T3] {
+	return Triple[T1, T2, T3]{first, second, third}
+}
+
+func (t Triple[T1, T2, T3]) Unpack() (T1, T2, T3) {
+	return t.First, t.Second, t.Third
+}
+
+func (t Triple[T1, T2, T3]) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", t.First, t.Second, t.Third)
+}
+
 // Tuple types
 
 type Tuple4[T1 any, T2 any, T3 any, T4 any] struct {
@@ -24,6 +50,18 @@ type Tuple4[T1 any, T2 any, T3 any, T4 any] struct {
 	Fourth T4
 }
 
+func MakeTuple4[T1 any, T2 any, T3 any, T4 any](first T1, second T2, third T3, fourth T4) Tuple4[T1, T2, T3, T4] {
+	return Tuple4[T1, T2, T3, T4]{first, second, third, fourth}
+}
+
+func (t Tuple4[T1, T2, T3, T4]) Unpack() (T1, T2, T3, T4) {
+	return t.First, t.Second, t.Third, t.Fourth
+}
+
+func (t Tuple4[T1, T2, T3, T4]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v)", t.First, t.Second, t.Third, t.Fourth)
+}
+
 type Tuple5[T1 any, T2 any, T3 any, T4 any, T5 any] struct {
 	First  T1
 	Second T2
@@ -32,4 +70,16 @@ type Tuple5[T1 any, T2 any, T3 any, T4 any, T5 any] struct {
 	Fifth  T5
 }
 
+func MakeTuple5[T1 any, T2 any, T3 any, T4 any, T5 any](first T1, second T2, third T3, fourth T4, fifth T5) Tuple5[T1, T2, T3, T4, T5] {
+	return Tuple5[T1, T2, T3, T4, T5]{first, second, third, fourth, fifth}
+}
+
+func (t Tuple5[T1, T2, T3, T4, T5]) Unpack() (T1, T2, T3, T4, T5) {
+	return t.First, t.Second, t.Third, t.Fourth, t.Fifth
+}
+
+func (t Tuple5[T1, T2, T3, T4, T5]) String() string {
+	return fmt.Sprintf("(%v, %v, %v, %v, %v)", t.First, t.Second, t.Third, t.Fourth, t.Fifth)
+}
+
 // That's enough
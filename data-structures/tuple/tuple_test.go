@@ -0,0 +1,180 @@
+package tuple
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"maps"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+func TestPairComparator(t *testing.T) {
+	pairs := []Pair[int, string]{
+		MakePair(2, "a"), MakePair(1, "b"), MakePair(2, "0"), MakePair(1, "a"),
+	}
+	c := PairComparator[int, string, comparator.Less[int], comparator.Less[string]]{}
+	slices.SortFunc(pairs, comparator.CompareFunc[Pair[int, string]](c))
+	want := []Pair[int, string]{{1, "a"}, {1, "b"}, {2, "0"}, {2, "a"}}
+	if !slices.Equal(pairs, want) {
+		t.Errorf("Sorted pairs = %v, want %v", pairs, want)
+	}
+
+	desc := NewPairComparator[int, string](comparator.Greater[int]{}, comparator.Less[string]{})
+	if !desc.Less(MakePair(2, "z"), MakePair(1, "a")) {
+		t.Error("Expected (2, z) < (1, a) with a descending first field")
+	}
+	if desc.Less(MakePair(1, "a"), MakePair(1, "a")) {
+		t.Error("Expected equal pairs not to be less than each other")
+	}
+}
+
+func TestTupleComparators(t *testing.T) {
+	type L = comparator.Less[int]
+	triple := TripleComparator[int, int, int, L, L, L]{}
+	if !triple.Less(MakeTriple(1, 2, 3), MakeTriple(1, 2, 4)) {
+		t.Error("Expected the third field to break the tie")
+	}
+	t4 := Tuple4Comparator[int, int, int, int, L, L, L, L]{}
+	if got := t4.Compare(MakeTuple4(1, 2, 3, 4), MakeTuple4(1, 2, 3, 4)); got != 0 {
+		t.Errorf("Expected equal tuples to compare 0, got %d", got)
+	}
+	t5 := NewTuple5Comparator[int, int, int, int, int](L{}, L{}, L{}, L{}, comparator.Greater[int]{})
+	if !t5.Less(MakeTuple5(0, 0, 0, 0, 1), MakeTuple5(0, 0, 0, 0, 0)) {
+		t.Error("Expected the fifth field to be compared in descending order")
+	}
+}
+
+func TestUnpackAndString(t *testing.T) {
+	a, b := MakePair(1, "x").Unpack()
+	if a != 1 || b != "x" {
+		t.Errorf("Unpack() = %v, %v", a, b)
+	}
+	tests := []struct {
+		got, want string
+	}{
+		{MakePair(1, "x").String(), "(1, x)"},
+		{fmt.Sprint(MakeTriple(1, 2.5, true)), "(1, 2.5, true)"},
+		{MakeTuple4('a', 2, 3, 4).String(), "(97, 2, 3, 4)"},
+		{MakeTuple5(1, 2, 3, 4, MakePair(5, 6)).String(), "(1, 2, 3, 4, (5, 6))"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("String() = %s, want %s", tt.got, tt.want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	p := MakePair(1, MakeTriple("a", true, []int{2, 3}))
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Unexpected error on Marshal: %v", err)
+	}
+	if string(data) != `[1,["a",true,[2,3]]]` {
+		t.Errorf("Unexpected encoding %s", data)
+	}
+
+	var got Pair[int, Triple[string, bool, []int]]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unexpected error on Unmarshal: %v", err)
+	}
+	if got.First != 1 || got.Second.First != "a" || !slices.Equal(got.Second.Third, []int{2, 3}) {
+		t.Errorf("Round trip gave %v", got)
+	}
+
+	// null leaves the tuple as it was, at the top level and nested
+	kept := MakePair(7, MakePair("x", 8))
+	if err := json.Unmarshal([]byte(`null`), &kept); err != nil || kept != MakePair(7, MakePair("x", 8)) {
+		t.Errorf("Expected null to leave %v untouched, got error %v", kept, err)
+	}
+	if err := json.Unmarshal([]byte(`[9,null]`), &kept); err != nil || kept != MakePair(9, MakePair("x", 8)) {
+		t.Errorf("Expected a null field to be left untouched, got %v, %v", kept, err)
+	}
+
+	for _, bad := range []string{`[1]`, `[1,"a",2]`, `{"First":1}`, `["a","b"]`} {
+		var p Pair[int, string]
+		if err := json.Unmarshal([]byte(bad), &p); err == nil {
+			t.Errorf("Expected error decoding %s", bad)
+		}
+	}
+}
+
+func TestZip(t *testing.T) {
+	zipped := slices.Collect(Zip(slices.Values([]int{1, 2, 3}), slices.Values([]string{"a", "b"})))
+	want := []Pair[int, string]{{1, "a"}, {2, "b"}}
+	if !slices.Equal(zipped, want) {
+		t.Errorf("Zip = %v, want %v", zipped, want)
+	}
+
+	firsts, seconds := Unzip(slices.Values(zipped))
+	if got := slices.Collect(firsts); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Unzip firsts = %v", got)
+	}
+	if got := slices.Collect(seconds); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Unzip seconds = %v", got)
+	}
+
+	m := map[string]int{"a": 1, "b": 2}
+	if got := maps.Collect(ToSeq2(FromSeq2(maps.All(m)))); !maps.Equal(got, m) {
+		t.Errorf("Round trip through pairs gave %v", got)
+	}
+}
+
+// threeWayOnly fails the test if the tuple comparators fall back to Less
+type threeWayOnly struct{ t *testing.T }
+
+func (c threeWayOnly) Less(a, b int) bool {
+	c.t.Error("Expected the field to be compared with Compare")
+	return a < b
+}
+
+func (c threeWayOnly) Compare(a, b int) int {
+	return comparator.Less[int]{}.Compare(a, b)
+}
+
+func TestComparatorsUseThreeWay(t *testing.T) {
+	c := NewPairComparator[int, int](threeWayOnly{t}, threeWayOnly{t})
+	if got := c.Compare(MakePair(1, 2), MakePair(1, 3)); got != -1 {
+		t.Errorf("Expected (1, 2) to compare -1 against (1, 3), got %d", got)
+	}
+}
+
+func TestHash(t *testing.T) {
+	seed := maphash.MakeSeed()
+	h := PairHasher[string, string, StringHasher[string], StringHasher[string]]{}
+	if Hash(seed, h, MakePair("ab", "c")) != Hash(seed, h, MakePair("ab", "c")) {
+		t.Error("Expected equal pairs to hash alike")
+	}
+	if Hash(seed, h, MakePair("ab", "c")) == Hash(seed, h, MakePair("a", "bc")) {
+		t.Error("Expected the fields not to run into each other")
+	}
+
+	floats := NewPairHasher[float64, bool](FloatHasher[float64]{}, BoolHasher{})
+	if Hash(seed, floats, MakePair(0.0, true)) != Hash(seed, floats, MakePair(math.Copysign(0, -1), true)) {
+		t.Error("Expected 0 and -0 to hash alike")
+	}
+
+	// tuple hashers nest, and the hashes can key a map of buckets
+	type I = IntHasher[int]
+	nested := NewTripleHasher[Pair[int, int], int, string](NewPairHasher[int, int](I{}, I{}), I{}, StringHasher[string]{})
+	buckets := map[uint64][]Triple[Pair[int, int], int, string]{}
+	for i := 0; i < 100; i++ {
+		v := MakeTriple(MakePair(i, i%7), i%3, "x")
+		key := Hash(seed, nested, v)
+		buckets[key] = append(buckets[key], v)
+	}
+	if len(buckets) != 100 {
+		t.Errorf("Expected 100 distinct hashes, got %d", len(buckets))
+	}
+
+	t5 := NewTuple5Hasher[int, int, int, int, uint8](I{}, I{}, I{}, I{}, IntHasher[uint8]{})
+	t4 := Tuple4Hasher[int, int, int, int, I, I, I, I]{}
+	if Hash(seed, t5, MakeTuple5(1, 2, 3, 4, uint8(5))) == Hash(seed, t5, MakeTuple5(1, 2, 3, 4, uint8(6))) ||
+		Hash(seed, t4, MakeTuple4(1, 2, 3, 4)) == Hash(seed, t4, MakeTuple4(4, 3, 2, 1)) {
+		t.Error("Expected different tuples to hash differently")
+	}
+}
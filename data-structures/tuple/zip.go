@@ -0,0 +1,59 @@
+package tuple
+
+import "iter"
+
+// Zip pairs up the elements of a and b in order, stopping when either runs out
+func Zip[T1 any, T2 any](a iter.Seq[T1], b iter.Seq[T2]) iter.Seq[Pair[T1, T2]] {
+	return func(yield func(Pair[T1, T2]) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for x := range a {
+			y, ok := next()
+			if !ok || !yield(Pair[T1, T2]{x, y}) {
+				return
+			}
+		}
+	}
+}
+
+// Unzip splits a sequence of pairs into the sequences of their fields. Each of the
+// returned sequences iterates over s on its own.
+func Unzip[T1 any, T2 any](s iter.Seq[Pair[T1, T2]]) (iter.Seq[T1], iter.Seq[T2]) {
+	firsts := func(yield func(T1) bool) {
+		for p := range s {
+			if !yield(p.First) {
+				return
+			}
+		}
+	}
+	seconds := func(yield func(T2) bool) {
+		for p := range s {
+			if !yield(p.Second) {
+				return
+			}
+		}
+	}
+	return firsts, seconds
+}
+
+// FromSeq2 turns each key-value pair of s into a Pair, e.g. for maps.All
+func FromSeq2[K any, V any](s iter.Seq2[K, V]) iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for k, v := range s {
+			if !yield(Pair[K, V]{k, v}) {
+				return
+			}
+		}
+	}
+}
+
+// ToSeq2 is the inverse of FromSeq2
+func ToSeq2[K any, V any](s iter.Seq[Pair[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p := range s {
+			if !yield(p.First, p.Second) {
+				return
+			}
+		}
+	}
+}
@@ -2,7 +2,16 @@ package vector
 
 import (
 	"errors"
+	"fmt"
 	"iter"
+	"slices"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
+)
+
+var (
+	ErrOutOfBounds = errors.New("index out of bounds")
+	ErrEmpty       = errors.New("vector is empty")
 )
 
 type Vector[T any] []T
@@ -54,8 +63,63 @@ func (v *Vector[T]) Reserve(n int) {
 		return
 	}
 	t := NewWithCapacity[T](n)
-	copy(t, *v)
-	*v = t
+	*v = append(t, *v...)
+}
+
+// Checked accessors. Indexing a vector directly, PopBack and Remove panic on bad
+// indices, these return ErrOutOfBounds or ErrEmpty instead.
+
+func (v Vector[T]) checkIndex(i int) error {
+	if i < 0 || i >= v.Size() {
+		return fmt.Errorf("%w: index %v for vector of size %v", ErrOutOfBounds, i, v.Size())
+	}
+	return nil
+}
+
+func (v Vector[T]) At(i int) (ret T, err error) {
+	if err = v.checkIndex(i); err != nil {
+		return
+	}
+	return v[i], nil
+}
+
+func (v Vector[T]) Set(i int, x T) error {
+	if err := v.checkIndex(i); err != nil {
+		return err
+	}
+	v[i] = x
+	return nil
+}
+
+func (v Vector[T]) Front() (ret T, err error) {
+	if v.IsEmpty() {
+		return ret, ErrEmpty
+	}
+	return v[0], nil
+}
+
+func (v Vector[T]) Back() (ret T, err error) {
+	if v.IsEmpty() {
+		return ret, ErrEmpty
+	}
+	return v[v.Size()-1], nil
+}
+
+// TryPopBack removes the last element and returns it
+func (v *Vector[T]) TryPopBack() (ret T, err error) {
+	if ret, err = v.Back(); err != nil {
+		return
+	}
+	v.PopBack()
+	return ret, nil
+}
+
+func (v *Vector[T]) TryRemove(i int) error {
+	if err := v.checkIndex(i); err != nil {
+		return err
+	}
+	v.Remove(i)
+	return nil
 }
 
 // Now let's implement some common snippets from this cheatsheet: https://ueokande.github.io/go-slice-tricks/
@@ -72,6 +136,123 @@ func Copy[T any](dest Vector[T], src Vector[T]) error {
 	return nil
 }
 
+// Insert inserts xs before position i, so xs[0] ends up at index i. i can be Size(),
+// which appends.
+func (v *Vector[T]) Insert(i int, xs ...T) error {
+	if i < 0 || i > v.Size() {
+		return fmt.Errorf("%w: insert position %v for vector of size %v", ErrOutOfBounds, i, v.Size())
+	}
+	*v = slices.Insert(*v, i, xs...)
+	return nil
+}
+
+// RemoveRange removes the elements in [l, r)
+func (v *Vector[T]) RemoveRange(l, r int) error {
+	if l < 0 || r > v.Size() || l > r {
+		return fmt.Errorf("%w: range [%v, %v) for vector of size %v", ErrOutOfBounds, l, r, v.Size())
+	}
+	*v = slices.Delete(*v, l, r)
+	return nil
+}
+
+// Filter returns a new vector with the elements for which keep returns true
+func (v Vector[T]) Filter(keep func(T) bool) Vector[T] {
+	ans := New[T]()
+	for _, x := range v {
+		if keep(x) {
+			ans.PushBack(x)
+		}
+	}
+	return ans
+}
+
+// FilterInPlace keeps only the elements for which keep returns true, reusing the
+// storage of v
+func (v *Vector[T]) FilterInPlace(keep func(T) bool) {
+	*v = slices.DeleteFunc(*v, func(x T) bool {
+		return !keep(x)
+	})
+}
+
+func Map[T any, U any](v Vector[T], f func(T) U) Vector[U] {
+	ans := NewWithCapacity[U](v.Size())
+	for _, x := range v {
+		ans.PushBack(f(x))
+	}
+	return ans
+}
+
+// Reduce folds the elements from left to right, starting from init
+func Reduce[T any, A any](v Vector[T], init A, f func(A, T) A) A {
+	acc := init
+	for _, x := range v {
+		acc = f(acc, x)
+	}
+	return acc
+}
+
+func (v Vector[T]) Reverse() {
+	slices.Reverse(v)
+}
+
+// Rotate moves every element k positions to the left, wrapping around, so the element
+// at index k becomes the first. A negative k rotates to the right.
+func (v Vector[T]) Rotate(k int) {
+	if v.IsEmpty() {
+		return
+	}
+	k = ((k % v.Size()) + v.Size()) % v.Size()
+	// reversing both parts and then the whole vector rotates in place
+	slices.Reverse(v[:k])
+	slices.Reverse(v[k:])
+	slices.Reverse(v)
+}
+
+// DedupFunc replaces each run of consecutive equal elements with its first element
+func (v *Vector[T]) DedupFunc(eq func(a, b T) bool) {
+	*v = slices.CompactFunc(*v, eq)
+}
+
+// Dedup replaces each run of consecutive equal elements with a single copy. On a
+// sorted vector it removes all duplicates.
+func Dedup[T comparable](v *Vector[T]) {
+	*v = slices.Compact(*v)
+}
+
+// IndexFunc returns the index of the first element satisfying f, or -1 if none does
+func (v Vector[T]) IndexFunc(f func(T) bool) int {
+	return slices.IndexFunc(v, f)
+}
+
+func Contains[T comparable](v Vector[T], x T) bool {
+	return slices.Contains(v, x)
+}
+
+// BinarySearch searches x in a vector sorted according to c. It returns the position
+// of the first element not less than x, and whether that element is equal to x.
+func (v Vector[T]) BinarySearch(x T, c comparator.Comparator[T]) (int, bool) {
+	lo, hi := 0, v.Size()
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if c.Less(v[mid], x) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < v.Size() && !c.Less(x, v[lo])
+}
+
+// Shrink reallocates the vector so its capacity matches its size
+func (v *Vector[T]) Shrink() {
+	if v.Capacity() == v.Size() {
+		return
+	}
+	t := NewWithSize[T](v.Size())
+	copy(t, *v)
+	*v = t
+}
+
 // Iterations
 
 func (v Vector[T]) Values() func(yield func(T) bool) {
@@ -105,3 +286,34 @@ func Collect[T any](seq iter.Seq[T]) Vector[T] {
 	ans.AppendSeq(seq)
 	return ans
 }
+
+// Chunk yields consecutive subvectors of n elements, the last one possibly shorter.
+// The chunks share the storage of v.
+func (v Vector[T]) Chunk(n int) (iter.Seq[Vector[T]], error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("chunk size %v must be positive", n)
+	}
+	return func(yield func(Vector[T]) bool) {
+		for i := 0; i < v.Size(); i += n {
+			end := min(i+n, v.Size())
+			if !yield(v[i:end:end]) {
+				return
+			}
+		}
+	}, nil
+}
+
+// Window yields every subvector of n consecutive elements, from left to right. It
+// yields nothing if v has fewer than n elements. The windows share the storage of v.
+func (v Vector[T]) Window(n int) (iter.Seq[Vector[T]], error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("window size %v must be positive", n)
+	}
+	return func(yield func(Vector[T]) bool) {
+		for i := 0; i+n <= v.Size(); i++ {
+			if !yield(v[i : i+n : i+n]) {
+				return
+			}
+		}
+	}, nil
+}
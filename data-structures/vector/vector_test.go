@@ -1,8 +1,13 @@
 package vector
 
 import (
+	"errors"
 	"slices"
+	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/lucasturci/everything-go/data-structures/comparator"
 )
 
 func TestNew(t *testing.T) {
@@ -253,3 +258,327 @@ func TestVectorCollect(t *testing.T) {
 		}
 	}
 }
+
+func equal[T comparable](v Vector[T], want ...T) bool {
+	return slices.Equal(v, want)
+}
+
+func TestInsert(t *testing.T) {
+	v := NewWithElements([]int{1, 4})
+	if err := v.Insert(1, 2, 3); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	v.Insert(4, 5)
+	v.Insert(0)
+	if !equal(v, 1, 2, 3, 4, 5) {
+		t.Errorf("Expected [1 2 3 4 5], got %v", v)
+	}
+	for _, i := range []int{-1, 6} {
+		if err := v.Insert(i, 0); err == nil {
+			t.Errorf("Expected error on Insert(%d)", i)
+		}
+	}
+}
+
+func TestRemoveRange(t *testing.T) {
+	v := NewWithElements([]int{1, 2, 3, 4, 5})
+	if err := v.RemoveRange(1, 3); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !equal(v, 1, 4, 5) {
+		t.Errorf("Expected [1 4 5], got %v", v)
+	}
+	for _, rg := range [][2]int{{-1, 1}, {2, 4}, {2, 1}} {
+		if err := v.RemoveRange(rg[0], rg[1]); err == nil {
+			t.Errorf("Expected error on RemoveRange(%d, %d)", rg[0], rg[1])
+		}
+	}
+}
+
+func TestFilterMapReduce(t *testing.T) {
+	v := NewWithElements([]int{1, 2, 3, 4, 5, 6})
+	even := func(x int) bool { return x%2 == 0 }
+
+	if got := v.Filter(even); !equal(got, 2, 4, 6) {
+		t.Errorf("Filter = %v, want [2 4 6]", got)
+	}
+	if !equal(v, 1, 2, 3, 4, 5, 6) {
+		t.Errorf("Filter should not modify the vector, got %v", v)
+	}
+
+	strs := Map(v, strconv.Itoa)
+	if !equal(strs, "1", "2", "3", "4", "5", "6") {
+		t.Errorf("Map = %v", strs)
+	}
+	if got := Reduce(v, "", func(acc string, x int) string { return acc + strconv.Itoa(x) }); got != "123456" {
+		t.Errorf("Reduce = %s, want 123456", got)
+	}
+
+	v.FilterInPlace(even)
+	if !equal(v, 2, 4, 6) {
+		t.Errorf("FilterInPlace = %v, want [2 4 6]", v)
+	}
+}
+
+func TestReverseRotate(t *testing.T) {
+	tests := []struct {
+		k        int
+		expected []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}},
+		{2, []int{3, 4, 5, 1, 2}},
+		{-1, []int{5, 1, 2, 3, 4}},
+		{7, []int{3, 4, 5, 1, 2}},
+	}
+	for _, tt := range tests {
+		v := NewWithElements([]int{1, 2, 3, 4, 5})
+		v.Rotate(tt.k)
+		if !slices.Equal(v, tt.expected) {
+			t.Errorf("Rotate(%d) = %v, want %v", tt.k, v, tt.expected)
+		}
+	}
+	New[int]().Rotate(3)
+
+	v := NewWithElements([]int{1, 2, 3})
+	v.Reverse()
+	if !equal(v, 3, 2, 1) {
+		t.Errorf("Reverse = %v, want [3 2 1]", v)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	v := NewWithElements([]int{1, 1, 2, 2, 2, 1, 3})
+	Dedup(&v)
+	if !equal(v, 1, 2, 1, 3) {
+		t.Errorf("Dedup = %v, want [1 2 1 3]", v)
+	}
+
+	w := NewWithElements([]string{"a", "A", "b", "B", "c"})
+	w.DedupFunc(strings.EqualFold)
+	if !equal(w, "a", "b", "c") {
+		t.Errorf("DedupFunc = %v, want [a b c]", w)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	v := NewWithElements([]int{1, 3, 3, 5, 7})
+	if i := v.IndexFunc(func(x int) bool { return x > 3 }); i != 3 {
+		t.Errorf("IndexFunc = %d, want 3", i)
+	}
+	if i := v.IndexFunc(func(x int) bool { return x > 10 }); i != -1 {
+		t.Errorf("IndexFunc = %d, want -1", i)
+	}
+	if !Contains(v, 5) || Contains(v, 4) {
+		t.Error("Contains gave wrong results")
+	}
+
+	tests := []struct {
+		x     int
+		pos   int
+		found bool
+	}{
+		{0, 0, false},
+		{3, 1, true},
+		{4, 3, false},
+		{7, 4, true},
+		{8, 5, false},
+	}
+	for _, tt := range tests {
+		pos, found := v.BinarySearch(tt.x, comparator.Less[int]{})
+		if pos != tt.pos || found != tt.found {
+			t.Errorf("BinarySearch(%d) = (%d, %v), want (%d, %v)", tt.x, pos, found, tt.pos, tt.found)
+		}
+	}
+
+	desc := NewWithElements([]int{9, 5, 1})
+	if pos, found := desc.BinarySearch(5, comparator.Greater[int]{}); pos != 1 || !found {
+		t.Errorf("BinarySearch on descending vector = (%d, %v), want (1, true)", pos, found)
+	}
+}
+
+func TestChunkWindow(t *testing.T) {
+	v := NewWithElements([]int{1, 2, 3, 4, 5})
+
+	chunks, err := v.Chunk(2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var got [][]int
+	for c := range chunks {
+		got = append(got, c)
+	}
+	if len(got) != 3 || !slices.Equal(got[0], []int{1, 2}) || !slices.Equal(got[2], []int{5}) {
+		t.Errorf("Chunk(2) = %v", got)
+	}
+
+	windows, err := v.Window(3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got = nil
+	for w := range windows {
+		got = append(got, w)
+	}
+	if len(got) != 3 || !slices.Equal(got[0], []int{1, 2, 3}) || !slices.Equal(got[2], []int{3, 4, 5}) {
+		t.Errorf("Window(3) = %v", got)
+	}
+
+	// appending to a chunk must not overwrite the next one
+	chunks, _ = v.Chunk(2)
+	for c := range chunks {
+		c.PushBack(0)
+		break
+	}
+	if !equal(v, 1, 2, 3, 4, 5) {
+		t.Errorf("Appending to a chunk modified the vector: %v", v)
+	}
+
+	if _, err := v.Chunk(0); err == nil {
+		t.Error("Expected error on Chunk(0)")
+	}
+	if _, err := v.Window(-1); err == nil {
+		t.Error("Expected error on Window(-1)")
+	}
+}
+
+func TestShrink(t *testing.T) {
+	v := NewWithCapacity[int](100)
+	v.PushBack(1)
+	v.PushBack(2)
+	v.Shrink()
+	if v.Capacity() != 2 || !equal(v, 1, 2) {
+		t.Errorf("Expected [1 2] with capacity 2, got %v with capacity %d", v, v.Capacity())
+	}
+}
+
+func TestReservePreservesContents(t *testing.T) {
+	v := NewWithElements([]int{1, 2, 3})
+	v.Reserve(10)
+	if v.Capacity() != 10 || !equal(v, 1, 2, 3) {
+		t.Errorf("Expected [1 2 3] with capacity 10, got %v with capacity %d", v, v.Capacity())
+	}
+}
+
+func TestCheckedAccessors(t *testing.T) {
+	v := New[int]()
+	if _, err := v.Front(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on Front, got %v", err)
+	}
+	if _, err := v.Back(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on Back, got %v", err)
+	}
+	if _, err := v.TryPopBack(); err != ErrEmpty {
+		t.Errorf("Expected ErrEmpty on TryPopBack, got %v", err)
+	}
+
+	v.PushBack(10)
+	v.PushBack(20)
+	if err := v.Set(1, 30); err != nil {
+		t.Errorf("Unexpected error on Set: %v", err)
+	}
+	if x, err := v.At(1); err != nil || x != 30 {
+		t.Errorf("Expected At(1) to be 30, got %d, %v", x, err)
+	}
+	if x, _ := v.Front(); x != 10 {
+		t.Errorf("Expected front 10, got %d", x)
+	}
+	if x, _ := v.TryPopBack(); x != 30 || v.Size() != 1 {
+		t.Errorf("Expected TryPopBack to return 30 and leave 1 element, got %d and %d", x, v.Size())
+	}
+
+	for _, i := range []int{-1, 1} {
+		if _, err := v.At(i); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("Expected ErrOutOfBounds on At(%d), got %v", i, err)
+		}
+		if err := v.Set(i, 0); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("Expected ErrOutOfBounds on Set(%d), got %v", i, err)
+		}
+		if err := v.TryRemove(i); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("Expected ErrOutOfBounds on TryRemove(%d), got %v", i, err)
+		}
+	}
+	if err := v.Insert(5, 1); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Expected ErrOutOfBounds on Insert, got %v", err)
+	}
+	if err := v.TryRemove(0); err != nil || !v.IsEmpty() {
+		t.Errorf("Expected TryRemove(0) to empty the vector, got %v", err)
+	}
+}
+
+// FuzzOperations runs the operations encoded in ops against a vector and a plain slice,
+// and checks both agree. Each operation is an opcode byte followed by an argument byte.
+func FuzzOperations(f *testing.F) {
+	f.Add([]byte{0, 1, 0, 2, 3, 0, 4, 1, 2, 0, 5, 9})
+	f.Add([]byte{6, 3, 0, 7, 1, 255, 5, 0})
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		v := New[int]()
+		var naive []int
+		for k := 0; k+1 < len(ops); k += 2 {
+			arg := int(ops[k+1]) - 8 // reach negative indices too
+			switch ops[k] % 8 {
+			case 0:
+				v.PushBack(arg)
+				naive = append(naive, arg)
+			case 1:
+				x, err := v.TryPopBack()
+				if len(naive) == 0 {
+					if err != ErrEmpty {
+						t.Fatalf("Expected ErrEmpty, got %v", err)
+					}
+					continue
+				}
+				if err != nil || x != naive[len(naive)-1] {
+					t.Fatalf("TryPopBack() = %d, %v, want %d", x, err, naive[len(naive)-1])
+				}
+				naive = naive[:len(naive)-1]
+			case 2:
+				x, err := v.At(arg)
+				if arg < 0 || arg >= len(naive) {
+					if !errors.Is(err, ErrOutOfBounds) {
+						t.Fatalf("Expected ErrOutOfBounds on At(%d), got %v", arg, err)
+					}
+				} else if err != nil || x != naive[arg] {
+					t.Fatalf("At(%d) = %d, %v, want %d", arg, x, err, naive[arg])
+				}
+			case 3:
+				err := v.Set(arg, k)
+				if arg < 0 || arg >= len(naive) {
+					if !errors.Is(err, ErrOutOfBounds) {
+						t.Fatalf("Expected ErrOutOfBounds on Set(%d), got %v", arg, err)
+					}
+				} else {
+					naive[arg] = k
+				}
+			case 4:
+				err := v.TryRemove(arg)
+				if arg < 0 || arg >= len(naive) {
+					if !errors.Is(err, ErrOutOfBounds) {
+						t.Fatalf("Expected ErrOutOfBounds on TryRemove(%d), got %v", arg, err)
+					}
+				} else {
+					naive = slices.Delete(naive, arg, arg+1)
+				}
+			case 5:
+				err := v.Insert(arg, k, k)
+				if arg < 0 || arg > len(naive) {
+					if !errors.Is(err, ErrOutOfBounds) {
+						t.Fatalf("Expected ErrOutOfBounds on Insert(%d), got %v", arg, err)
+					}
+				} else {
+					naive = slices.Insert(naive, arg, k, k)
+				}
+			case 6:
+				v.Reserve(arg)
+			case 7:
+				v.Shrink()
+			}
+			if !slices.Equal(v, naive) {
+				t.Fatalf("After op %d: vector %v, want %v", k/2, v, naive)
+			}
+			front, err := v.Front()
+			if (err == nil) != (len(naive) > 0) || (err == nil && front != naive[0]) {
+				t.Fatalf("Front() = %d, %v on %v", front, err, naive)
+			}
+		}
+	})
+}